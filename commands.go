@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/onkarbanerjee/crd-operator/encryption"
+	customconfigv1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	customconfigv2 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v2"
+	"github.com/onkarbanerjee/crd-operator/signature"
+	log "github.com/sirupsen/logrus"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+// runCommand runs the command named by the first argument instead of the
+// operator, e.g. crd-operator rollback <namespace> <name> <revision>
+func runCommand(args []string) {
+	switch args[0] {
+	case "rollback":
+		rollback(args[1:])
+	case "encrypt":
+		encrypt(args[1:])
+	case "genkey":
+		genkey(args[1:])
+	case "sign":
+		sign(args[1:])
+	case "signing-key":
+		signingKey(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", args[0])
+		os.Exit(2)
+	}
+}
+
+// rollback sets spec.rollbackTo of a CustomConfig, which the operator then
+// rolls back like any other change
+func rollback(args []string) {
+	if len(args) != 3 {
+		fmt.Fprintln(os.Stderr, "usage: crd-operator rollback <namespace> <name> <revision>")
+		os.Exit(2)
+	}
+	ns, name := args[0], args[1]
+	revision, err := strconv.ParseInt(args[2], 10, 64)
+	if err != nil {
+		log.Fatalf("invalid revision %q: %v", args[2], err)
+	}
+
+	_, customconfigClient := getKubernetesClient()
+	patch := []byte(fmt.Sprintf(`{"spec":{"rollbackTo":%d}}`, revision))
+	_, err = customconfigClient.MtcilV1().CustomConfigs(ns).Patch(context.TODO(), name, types.MergePatchType, patch, meta_v1.PatchOptions{})
+	if err != nil {
+		log.Fatalf("rolling back customconfig %s/%s: %v", ns, name, err)
+	}
+	fmt.Printf("customconfig %s/%s rolling back to revision %d\n", ns, name, revision)
+}
+
+// encrypt prints the value sealed with the public key of the operator, for
+// spec.encryptedValue. The value is read from stdin when not given, not to
+// leave it in the shell history.
+func encrypt(args []string) {
+	if len(args) < 1 || len(args) > 2 {
+		fmt.Fprintln(os.Stderr, "usage: crd-operator encrypt <public-key-file> [value]")
+		os.Exit(2)
+	}
+	publicKey, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		log.Fatalf("reading public key: %v", err)
+	}
+
+	var value string
+	if len(args) == 2 {
+		value = args[1]
+	} else {
+		data, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			log.Fatalf("reading value: %v", err)
+		}
+		value = strings.TrimSuffix(string(data), "\n")
+	}
+
+	sealed, err := encryption.Encrypt(string(publicKey), value)
+	if err != nil {
+		log.Fatalf("encrypting value: %v", err)
+	}
+	fmt.Println(sealed)
+}
+
+// genkey writes a new private key of the operator to a file and prints its
+// public key
+func genkey(args []string) {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: crd-operator genkey <private-key-file>")
+		os.Exit(2)
+	}
+	key, err := encryption.GenerateKey()
+	if err != nil {
+		log.Fatalf("generating key: %v", err)
+	}
+	if err = ioutil.WriteFile(args[0], []byte(key.String()+"\n"), 0600); err != nil {
+		log.Fatalf("writing private key: %v", err)
+	}
+	fmt.Println(key.PublicKey())
+}
+
+// sign prints the signature of a CustomConfig manifest, to be set as its
+// mtcil.com/signature annotation. The namespace, name and spec are signed as
+// the operator stores them, as v1, the defaults being filled in later.
+func sign(args []string) {
+	if len(args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: crd-operator sign <private-key-file> <customconfig-manifest>")
+		os.Exit(2)
+	}
+	data, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		log.Fatalf("reading private key: %v", err)
+	}
+	key, err := signature.ParsePrivateKey(string(data))
+	if err != nil {
+		log.Fatal(err)
+	}
+	manifest, err := ioutil.ReadFile(args[1])
+	if err != nil {
+		log.Fatalf("reading manifest: %v", err)
+	}
+
+	cc := &customconfigv1.CustomConfig{}
+	meta := meta_v1.TypeMeta{}
+	if err = yaml.Unmarshal(manifest, &meta); err != nil {
+		log.Fatalf("decoding manifest: %v", err)
+	}
+	if meta.APIVersion == customconfigv2.SchemeGroupVersion.String() {
+		hub := &customconfigv2.CustomConfig{}
+		if err = yaml.Unmarshal(manifest, hub); err == nil {
+			err = cc.ConvertFrom(hub)
+		}
+	} else {
+		err = yaml.Unmarshal(manifest, cc)
+	}
+	if err != nil {
+		log.Fatalf("decoding manifest: %v", err)
+	}
+
+	// the namespace and name are signed, not to replay the signature
+	if cc.Namespace == "" || cc.Name == "" {
+		log.Fatal("the manifest must set metadata.namespace and metadata.name, which are signed")
+	}
+	sig, err := signature.Sign(key, cc)
+	if err != nil {
+		log.Fatalf("signing customconfig: %v", err)
+	}
+	fmt.Println(sig)
+}
+
+// signingKey writes a new ed25519 private key to a file and prints its
+// public key, to be trusted in the signing keys config map
+func signingKey(args []string) {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: crd-operator signing-key <private-key-file>")
+		os.Exit(2)
+	}
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		log.Fatalf("generating key: %v", err)
+	}
+	encoded := base64.StdEncoding.EncodeToString(private.Seed())
+	if err = ioutil.WriteFile(args[0], []byte(encoded+"\n"), 0600); err != nil {
+		log.Fatalf("writing private key: %v", err)
+	}
+	fmt.Println(base64.StdEncoding.EncodeToString(public))
+}
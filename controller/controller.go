@@ -77,6 +77,66 @@ func New(name string, client kubernetes.Interface, informer cache.SharedIndexInf
 	}
 }
 
+// NewEventHandler returns the informer event handler that queues an Item for
+// every resource added, updated or deleted, remembering the last state of
+// the deleted ones in deletedItems
+func NewEventHandler(queue workqueue.RateLimitingInterface, deletedItems *DeletedItems) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			// convert the resource object into a key (in this case
+			// we are just doing it in the format of 'namespace/name')
+			key, err := cache.MetaNamespaceKeyFunc(obj)
+			log.Infof("Add: %s", key)
+			if err == nil {
+				// add the key to the queue for the handler to get
+				queue.Add(&Item{Key: key, Event_type: CREATED})
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			key, err := cache.MetaNamespaceKeyFunc(newObj)
+			log.Infof("Update: %s", key)
+			if err == nil {
+				queue.Add(&Item{Key: key, Event_type: UPDATED})
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			// DeletionHandlingMetaNamsespaceKeyFunc is a helper function that allows
+			// us to check the DeletedFinalStateUnknown existence in the event that
+			// a resource was deleted but it is still contained in the index
+			//
+			// this then in turn calls MetaNamespaceKeyFunc
+			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+			log.Infof("Delete: %s", key)
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			deletedItems.Add(key, obj)
+			if err == nil {
+				queue.Add(&Item{Key: key, Event_type: DELETED})
+			}
+		},
+	}
+}
+
+// Enqueue queues an update of the resource with the given key, so that its
+// handler reconciles it again
+func (c *Controller) Enqueue(key string) {
+	c.queue.Add(&Item{Key: key, Event_type: UPDATED})
+}
+
+// EnqueueAfter queues an update of the resource with the given key once the
+// delay is over
+func (c *Controller) EnqueueAfter(key string, delay time.Duration) {
+	c.queue.AddAfter(&Item{Key: key, Event_type: UPDATED}, delay)
+}
+
+// EnqueueDeletedAfter queues the deletion of the resource with the given key
+// again once the delay is over, its handler getting the state it was deleted
+// in
+func (c *Controller) EnqueueDeletedAfter(key string, delay time.Duration) {
+	c.queue.AddAfter(&Item{Key: key, Event_type: DELETED}, delay)
+}
+
 // Run is the main path of execution for the controller loop
 func (c *Controller) Run(stopCh <-chan struct{}) {
 	// handle a panic with logging and exiting
@@ -0,0 +1,111 @@
+// Package encryption seals values with NaCl box, so that CustomConfigs can
+// be committed to Git with values only the operator can read. A sealed value
+// is the base64 of an ephemeral public key, a nonce and the box of the value
+// from the ephemeral key to the key of the operator.
+package encryption
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/nacl/box"
+)
+
+const (
+	keySize   = 32
+	nonceSize = 24
+)
+
+// Key is the key pair of the operator, which decrypts the sealed values
+type Key struct {
+	public, private [keySize]byte
+}
+
+// GenerateKey generates a new key pair
+func GenerateKey() (*Key, error) {
+	public, private, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &Key{public: *public, private: *private}, nil
+}
+
+// ParseKey parses a base64 private key, as written by Key.String
+func ParseKey(s string) (*Key, error) {
+	private, err := decodeKey(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %v", err)
+	}
+	k := &Key{private: *private}
+	curve25519.ScalarBaseMult(&k.public, &k.private)
+	return k, nil
+}
+
+// String returns the base64 private key
+func (k *Key) String() string {
+	return base64.StdEncoding.EncodeToString(k.private[:])
+}
+
+// PublicKey returns the base64 public key the values are encrypted with
+func (k *Key) PublicKey() string {
+	return base64.StdEncoding.EncodeToString(k.public[:])
+}
+
+// Decrypt opens the sealed value
+func (k *Key) Decrypt(sealed string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(sealed))
+	if err != nil {
+		return "", fmt.Errorf("encrypted value is not base64: %v", err)
+	}
+	if len(data) < keySize+nonceSize+box.Overhead {
+		return "", fmt.Errorf("encrypted value is too short")
+	}
+
+	var ephemeral [keySize]byte
+	var nonce [nonceSize]byte
+	copy(ephemeral[:], data[:keySize])
+	copy(nonce[:], data[keySize:keySize+nonceSize])
+	plain, ok := box.Open(nil, data[keySize+nonceSize:], &nonce, &ephemeral, &k.private)
+	if !ok {
+		return "", fmt.Errorf("encrypted value cannot be decrypted with the key of the operator")
+	}
+	return string(plain), nil
+}
+
+// Encrypt seals value for the base64 public key
+func Encrypt(publicKey, value string) (string, error) {
+	peer, err := decodeKey(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid public key: %v", err)
+	}
+	ephemeral, private, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	var nonce [nonceSize]byte
+	if _, err = io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return "", err
+	}
+
+	out := append(ephemeral[:], nonce[:]...)
+	out = box.Seal(out, []byte(value), &nonce, peer, private)
+	return base64.StdEncoding.EncodeToString(out), nil
+}
+
+// decodeKey decodes a base64 key
+func decodeKey(s string) (*[keySize]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != keySize {
+		return nil, fmt.Errorf("key has %d bytes instead of %d", len(data), keySize)
+	}
+	var key [keySize]byte
+	copy(key[:], data)
+	return &key, nil
+}
@@ -3,8 +3,13 @@ module github.com/onkarbanerjee/crd-operator
 go 1.15
 
 require (
+	github.com/google/gofuzz v1.0.0
+	github.com/pmezard/go-difflib v1.0.0
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/sirupsen/logrus v1.8.1
+	golang.org/x/crypto v0.0.0-20190820162420-60c769a6c586
 	k8s.io/api v0.17.3
 	k8s.io/apimachinery v0.17.3
 	k8s.io/client-go v0.17.3
+	sigs.k8s.io/yaml v1.1.0
 )
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"strconv"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	core_v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// RequireApprovalLabel marks the namespaces whose CustomConfig changes
+	// are only applied once approved
+	RequireApprovalLabel = "mtcil.com/require-approval"
+	// ApproveAnnotation approves the generation of a CustomConfig it holds
+	ApproveAnnotation = "mtcil.com/approve"
+	// ApprovedByAnnotation is the user who set ApproveAnnotation, recorded
+	// by the webhook
+	ApprovedByAnnotation = "mtcil.com/approved-by"
+	// ChangedByAnnotation is the user who last changed the spec, recorded by
+	// the webhook
+	ChangedByAnnotation = "mtcil.com/changed-by"
+)
+
+// RequiresApproval tells whether the CustomConfig changes in ns must be
+// approved
+func RequiresApproval(ns *core_v1.Namespace) bool {
+	_, ok := ns.Labels[RequireApprovalLabel]
+	return ok
+}
+
+// Approval returns the generation of cc approved by a user other than the
+// one who last changed its spec, and that user, 0 when there is none
+func Approval(cc *v1.CustomConfig) (int64, string) {
+	generation, err := strconv.ParseInt(cc.Annotations[ApproveAnnotation], 10, 64)
+	if err != nil {
+		return 0, ""
+	}
+	by := cc.Annotations[ApprovedByAnnotation]
+	if by == "" || by == cc.Annotations[ChangedByAnnotation] {
+		return 0, ""
+	}
+	return generation, by
+}
+
+// requiresApproval tells whether the changes to cc must be approved
+func (t *CCHandler) requiresApproval(cc *v1.CustomConfig) bool {
+	return t.RequiresApproval != nil && t.RequiresApproval(cc.Namespace)
+}
+
+// pendingApproval tells whether the changes to cc must be approved and its
+// generation is not. Nothing is approved when the webhook does not record
+// the approvals, nor is the projection of a ClusterCustomConfig, which has
+// no generation.
+func (t *CCHandler) pendingApproval(cc *v1.CustomConfig) bool {
+	if !t.requiresApproval(cc) {
+		return false
+	}
+	if !t.ApprovalsRecorded || cc.Generation == 0 {
+		return true
+	}
+	generation, _ := Approval(cc)
+	return generation != cc.Generation
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	authorization_v1 "k8s.io/api/authorization/v1"
+	errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ChangedByGroupsAnnotation lists the groups of the user who last changed
+// the spec, comma separated, recorded by the webhook
+const ChangedByGroupsAnnotation = "mtcil.com/changed-by-groups"
+
+// foreignTarget tells whether cc targets a namespace other than its own and
+// the one of the operator, which is only written when the user who last
+// changed cc may write it, whether writes are authorized or not
+func foreignTarget(cc *v1.CustomConfig) bool {
+	ns := cc.Spec.TargetNamespace
+	return ns != "" && ns != cc.Namespace && ns != operatorNamespace()
+}
+
+// authorizeWrite checks with a SubjectAccessReview that the user who last
+// changed the spec of cc could write the ConfigMap, or the Secret, cc
+// targets directly, and returns why not. An empty reason with an error is
+// a failure of the check itself.
+func (t *CCHandler) authorizeWrite(cc *v1.CustomConfig) (string, string, error) {
+	user := cc.Annotations[ChangedByAnnotation]
+	if user == "" {
+		return "", "UnknownAuthor", fmt.Errorf("the user who changed customconfig %s/%s is not known", cc.Namespace, cc.Name)
+	}
+	var groups []string
+	for _, g := range strings.Split(cc.Annotations[ChangedByGroupsAnnotation], ",") {
+		if g != "" {
+			groups = append(groups, g)
+		}
+	}
+
+	ns := TargetNamespace(cc)
+	resource, name := "configmaps", cc.Spec.ConfigmapName
+	var err error
+	if encrypted(cc) {
+		resource, name = "secrets", cc.Spec.SecretName
+		_, err = t.Client.CoreV1().Secrets(ns).Get(name, meta_v1.GetOptions{})
+	} else {
+		_, err = t.Client.CoreV1().ConfigMaps(ns).Get(name, meta_v1.GetOptions{})
+	}
+	verb := "update"
+	switch {
+	case cc.Spec.Immutable != nil && !encrypted(cc):
+		// every generation is a new ConfigMap
+		verb, name = "create", ""
+	case errors.IsNotFound(err):
+		verb = "create"
+	case err != nil:
+		return "", "", err
+	}
+
+	// a create names no object, which RBAC cannot restrict by name
+	reviewedName := name
+	if verb == "create" {
+		reviewedName = ""
+	}
+	review, err := t.Client.AuthorizationV1().SubjectAccessReviews().Create(&authorization_v1.SubjectAccessReview{
+		Spec: authorization_v1.SubjectAccessReviewSpec{
+			User:   user,
+			Groups: groups,
+			ResourceAttributes: &authorization_v1.ResourceAttributes{
+				Namespace: ns,
+				Verb:      verb,
+				Resource:  resource,
+				Name:      reviewedName,
+			},
+		},
+	})
+	if err != nil {
+		return "", "", err
+	}
+	target := fmt.Sprintf("%s %s/%s", resource, ns, name)
+	if name == "" {
+		target = fmt.Sprintf("%s in %s", resource, ns)
+	}
+	if !review.Status.Allowed {
+		why := ""
+		if review.Status.Reason != "" {
+			why = ": " + review.Status.Reason
+		}
+		return "", "Forbidden", fmt.Errorf("%s may not %s %s%s", user, verb, target, why)
+	}
+	return fmt.Sprintf("%s may %s %s", user, verb, target), "", nil
+}
@@ -0,0 +1,459 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	"github.com/onkarbanerjee/crd-operator/pkg/client/clientset/versioned"
+	log "github.com/sirupsen/logrus"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+// CCCHandler is the Handler of ClusterCustomConfigs. It projects each of them
+// into the namespaces matching its selector as a CustomConfig targeting that
+// namespace, written through the CCHandler.
+type CCCHandler struct {
+	CC *CCHandler
+	// CCClient is used to write the status of the ClusterCustomConfigs
+	CCClient versioned.Interface
+	// Namespaces lists the namespaces to match the selectors against
+	Namespaces corelisters.NamespaceLister
+	// Requeue syncs a ClusterCustomConfig again after a delay, which
+	// progressive rollouts need to move on to their next batch
+	Requeue func(name string, delay time.Duration)
+	// RequeueDeleted handles the deletion of a ClusterCustomConfig again
+	// after a delay, which the removals held by a freeze need. They are not
+	// held when it is nil.
+	RequeueDeleted func(name string, delay time.Duration)
+
+	mu      sync.Mutex
+	batches map[string]batch
+}
+
+// batch is the last batch of a rollout
+type batch struct {
+	value string
+	time  meta_v1.Time
+}
+
+// namespaceNameLabel is the label every namespace has its name in
+const namespaceNameLabel = "kubernetes.io/metadata.name"
+
+// defaultRolloutInterval is the pause between two batches of a rollout when
+// the ClusterCustomConfig does not say
+const defaultRolloutInterval = time.Minute
+
+// Init handles any handler initialization
+func (t *CCCHandler) Init() error {
+	log.Info("CCCHandler.Init")
+	return nil
+}
+
+// ObjectCreated is called when an object is created
+func (t *CCCHandler) ObjectCreated(obj interface{}) {
+	log.Info("CCCHandler.ObjectCreated")
+	if ccc, ok := obj.(*v1.ClusterCustomConfig); ok {
+		t.sync(ccc)
+	}
+}
+
+// ObjectDeleted is called when an object is deleted
+func (t *CCCHandler) ObjectDeleted(obj interface{}) {
+	log.Info("CCCHandler.ObjectDeleted")
+	ccc, ok := obj.(*v1.ClusterCustomConfig)
+	if !ok {
+		return
+	}
+	if t.CC.paused() {
+		t.CC.deferDelete("clustercustomconfig/"+ccc.Name, func() { t.ObjectDeleted(ccc) })
+		return
+	}
+	// a deletion requeued by a freeze is superseded by a ClusterCustomConfig
+	// created again since
+	if t.CCClient != nil {
+		if _, err := t.CCClient.MtcilV1().ClusterCustomConfigs().Get(context.TODO(), ccc.Name, meta_v1.GetOptions{}); err == nil {
+			log.Infof("clustercustomconfig %s was created again, not removing it", ccc.Name)
+			return
+		}
+	}
+	t.mu.Lock()
+	delete(t.batches, ccc.Name)
+	t.mu.Unlock()
+	t.CC.forgetReports("clustercustomconfig/" + ccc.Name + "/")
+
+	// the namespaces a freeze holds are tried again, the others are done
+	var retry time.Duration
+	for _, ns := range ccc.Status.Namespaces {
+		p := projection(ccc, ns, "")
+		if t.CC.policyViolation(p) != nil {
+			continue
+		}
+		if d, held := t.CC.removalHeld(p); held && t.RequeueDeleted != nil {
+			if retry == 0 || d < retry {
+				retry = d
+			}
+			continue
+		}
+		if _, err := t.CC.apply(p, true); err != nil {
+			log.Errorf("removing clustercustomconfig %s from namespace %s: %v", ccc.Name, ns, err)
+		}
+	}
+	if retry > 0 {
+		t.RequeueDeleted(ccc.Name, retry)
+	}
+}
+
+// ObjectUpdated is called when an object is updated
+func (t *CCCHandler) ObjectUpdated(obj interface{}) {
+	log.Info("CCCHandler.ObjectUpdated")
+	if ccc, ok := obj.(*v1.ClusterCustomConfig); ok {
+		t.sync(ccc)
+	}
+}
+
+// sync sets the key of ccc in every matching namespace and removes it from
+// the namespaces it was set in which no longer match. When a rollout is set,
+// a new value only reaches the namespaces batch by batch.
+func (t *CCCHandler) sync(ccc *v1.ClusterCustomConfig) {
+	if t.CC.paused() {
+		log.Infof("the operator is paused, not syncing clustercustomconfig %s", ccc.Name)
+		return
+	}
+
+	matching, err := t.matchingNamespaces(ccc)
+	if err != nil {
+		log.Errorf("listing namespaces of clustercustomconfig %s: %v", ccc.Name, err)
+		return
+	}
+
+	status := ccc.Status.DeepCopy()
+	set := map[string]v1.NamespaceStatus{}
+	for _, ns := range ccc.Status.Namespaces {
+		set[ns] = v1.NamespaceStatus{Namespace: ns}
+	}
+	for _, s := range ccc.Status.NamespaceStatuses {
+		set[s.Namespace] = s
+	}
+
+	for ns := range set {
+		if matching[ns] != nil {
+			continue
+		}
+		// a namespace protected since is left as it is
+		if t.CC.policyViolation(projection(ccc, ns, "")) != nil {
+			delete(set, ns)
+			continue
+		}
+		if t.held(ccc, ns) || !t.authorized(ccc, ns) {
+			continue
+		}
+		if _, err = t.CC.apply(projection(ccc, ns, ""), true); err != nil {
+			log.Errorf("removing clustercustomconfig %s from namespace %s: %v", ccc.Name, ns, err)
+			continue
+		}
+		delete(set, ns)
+		log.Infof("removed clustercustomconfig %s from namespace %s", ccc.Name, ns)
+	}
+
+	var pending []*core_v1.Namespace
+	for ns := range matching {
+		current, ok := set[ns]
+		if ccc.Spec.Rollout != nil && (!ok || current.Value != ccc.Spec.Value) {
+			pending = append(pending, matching[ns])
+			continue
+		}
+		t.setValue(ccc, ns, set)
+	}
+	if ccc.Spec.Rollout != nil {
+		status.Rollout = t.rollOut(ccc, pending, set, len(matching))
+	} else {
+		status.Rollout = nil
+	}
+
+	status.Namespaces = nil
+	status.NamespaceStatuses = nil
+	for ns := range set {
+		status.Namespaces = append(status.Namespaces, ns)
+	}
+	sort.Strings(status.Namespaces)
+	for _, ns := range status.Namespaces {
+		status.NamespaceStatuses = append(status.NamespaceStatuses, set[ns])
+	}
+	// a dry run sets nothing, which the status is not to tell otherwise
+	if t.CC.DryRun {
+		return
+	}
+	t.updateStatus(ccc, status)
+}
+
+// setValue sets the key of ccc to its value in namespace ns, recording it in
+// set, unless a ConfigFreeze holds that change or its author may not write
+// there
+func (t *CCCHandler) setValue(ccc *v1.ClusterCustomConfig, ns string, set map[string]v1.NamespaceStatus) bool {
+	if current, ok := set[ns]; (!ok || current.Value != ccc.Spec.Value) && t.held(ccc, ns) {
+		return false
+	}
+	if !t.authorized(ccc, ns) {
+		return false
+	}
+	// a ClusterCustomConfig has no generation a user could approve
+	if t.CC.requiresApproval(projection(ccc, ns, "")) {
+		log.Warnf("not setting clustercustomconfig %s in namespace %s, which requires approval", ccc.Name, ns)
+		return false
+	}
+	if _, err := t.CC.apply(projection(ccc, ns, ccc.Spec.Value), false); err != nil {
+		log.Errorf("setting clustercustomconfig %s in namespace %s: %v", ccc.Name, ns, err)
+		return false
+	}
+	if current, ok := set[ns]; !ok || current.Value != ccc.Spec.Value {
+		set[ns] = v1.NamespaceStatus{Namespace: ns, Value: ccc.Spec.Value, UpdateTime: meta_v1.Now()}
+	}
+	return true
+}
+
+// held tells whether a ConfigFreeze holds the changes of ccc to namespace
+// ns, which the emergency override annotation of ccc lets through
+func (t *CCCHandler) held(ccc *v1.ClusterCustomConfig, ns string) bool {
+	key := "clustercustomconfig/" + ccc.Name + "/" + ns + "/Frozen"
+	f, end := t.CC.activeFreeze(projection(ccc, ns, ""))
+	if f == nil {
+		t.CC.recordOnce(ccc, key, core_v1.EventTypeNormal, "Frozen", "")
+		return false
+	}
+	if override := ccc.Annotations[FreezeOverrideAnnotation]; override != "" {
+		log.Warnf("clustercustomconfig %s overrides configfreeze %s in namespace %s: %s", ccc.Name, f.Name, ns, override)
+		t.CC.recordOnce(ccc, key, core_v1.EventTypeNormal, "Frozen", "")
+		return false
+	}
+	message := fmt.Sprintf("changes to namespace %s held by configfreeze %s until %s", ns, f.Name, end.Format(time.RFC3339))
+	log.Infof("clustercustomconfig %s: %s", ccc.Name, message)
+	t.CC.recordOnce(ccc, key, core_v1.EventTypeNormal, "Frozen", message)
+	return true
+}
+
+// authorized tells whether the user who last changed ccc may write its
+// ConfigMap in namespace ns, when writes are to be authorized
+func (t *CCCHandler) authorized(ccc *v1.ClusterCustomConfig, ns string) bool {
+	if !t.CC.AuthorizeWrites {
+		return true
+	}
+	_, reason, err := t.CC.authorizeWrite(projection(ccc, ns, ccc.Spec.Value))
+	if err == nil {
+		return true
+	}
+	log.Errorf("not writing clustercustomconfig %s to namespace %s: %v", ccc.Name, ns, err)
+	if reason != "" {
+		recordEvent(t.CC.Recorder, ccc, core_v1.EventTypeWarning, reason, "not writing to namespace %s: %v", ns, err)
+	}
+	return false
+}
+
+// rollOut sets the value of ccc in the next batch of the pending namespaces,
+// once the interval since the previous batch is over, and returns the
+// progress of the rollout
+func (t *CCCHandler) rollOut(ccc *v1.ClusterCustomConfig, pending []*core_v1.Namespace, set map[string]v1.NamespaceStatus, total int) *v1.ProgressiveRolloutStatus {
+	rollout := ccc.Spec.Rollout
+	progress := &v1.ProgressiveRolloutStatus{
+		Phase:           v1.RolloutProgressing,
+		Value:           ccc.Spec.Value,
+		TotalNamespaces: int32(total),
+	}
+	// the batches of a previous value do not delay the ones of a new value
+	if previous := ccc.Status.Rollout; previous != nil && previous.Value == ccc.Spec.Value {
+		progress.LastBatchTime = previous.LastBatchTime
+	}
+	// the status read from the cache may not show the latest batch yet
+	if last, ok := t.lastBatch(ccc); ok && (progress.LastBatchTime == nil || last.After(progress.LastBatchTime.Time)) {
+		progress.LastBatchTime = &last
+	}
+
+	batchSize := 1
+	if rollout.BatchSize > 0 {
+		batchSize = int(rollout.BatchSize)
+	}
+	interval := defaultRolloutInterval
+	if rollout.Interval != nil {
+		interval = rollout.Interval.Duration
+	}
+
+	remaining := len(pending)
+	switch {
+	case remaining == 0:
+	case rollout.Paused:
+		progress.Phase = v1.RolloutPaused
+		log.Infof("rollout of clustercustomconfig %s is paused with %d namespaces pending", ccc.Name, remaining)
+	case progress.LastBatchTime != nil && time.Since(progress.LastBatchTime.Time) < interval:
+		t.requeue(ccc, interval-time.Since(progress.LastBatchTime.Time))
+	default:
+		sortNamespaces(pending, rollout.OrderLabel, rollout.Order)
+		if len(pending) > batchSize {
+			pending = pending[:batchSize]
+		}
+		for _, ns := range pending {
+			if t.setValue(ccc, ns.Name, set) {
+				remaining--
+				log.Infof("rolled clustercustomconfig %s out to namespace %s", ccc.Name, ns.Name)
+			}
+		}
+		now := meta_v1.Now()
+		progress.LastBatchTime = &now
+		t.recordBatch(ccc, now)
+		if remaining > 0 {
+			t.requeue(ccc, interval)
+		}
+	}
+
+	progress.UpdatedNamespaces = int32(total - remaining)
+	if remaining == 0 {
+		progress.Phase = v1.RolloutComplete
+	}
+	return progress
+}
+
+// lastBatch returns when the last batch of the value of ccc was rolled out
+// by this operator
+func (t *CCCHandler) lastBatch(ccc *v1.ClusterCustomConfig) (meta_v1.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	b, ok := t.batches[ccc.Name]
+	return b.time, ok && b.value == ccc.Spec.Value
+}
+
+// recordBatch remembers that a batch of the value of ccc was rolled out at now
+func (t *CCCHandler) recordBatch(ccc *v1.ClusterCustomConfig, now meta_v1.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.batches == nil {
+		t.batches = map[string]batch{}
+	}
+	t.batches[ccc.Name] = batch{value: ccc.Spec.Value, time: now}
+}
+
+// requeue syncs ccc again once delay is over
+func (t *CCCHandler) requeue(ccc *v1.ClusterCustomConfig, delay time.Duration) {
+	if t.Requeue != nil {
+		t.Requeue(ccc.Name, delay)
+	}
+}
+
+// sortNamespaces orders the namespaces by the position of the value of their
+// label in order, then by name
+func sortNamespaces(namespaces []*core_v1.Namespace, label string, order []string) {
+	rank := func(ns *core_v1.Namespace) int {
+		if label != "" {
+			for i, value := range order {
+				if ns.Labels[label] == value {
+					return i
+				}
+			}
+		}
+		return len(order)
+	}
+	sort.Slice(namespaces, func(i, j int) bool {
+		ri, rj := rank(namespaces[i]), rank(namespaces[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return namespaces[i].Name < namespaces[j].Name
+	})
+}
+
+// matchingNamespaces returns the existing namespaces selected by ccc, but
+// for the ones the policy of the operator protects
+func (t *CCCHandler) matchingNamespaces(ccc *v1.ClusterCustomConfig) (map[string]*core_v1.Namespace, error) {
+	selector := labels.Everything()
+	if ccc.Spec.NamespaceSelector != nil {
+		var err error
+		selector, err = meta_v1.LabelSelectorAsSelector(ccc.Spec.NamespaceSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	namespaces, err := t.Namespaces.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	matching := map[string]*core_v1.Namespace{}
+	for _, ns := range namespaces {
+		if ns.DeletionTimestamp != nil {
+			continue
+		}
+		key := "clustercustomconfig/" + ccc.Name + "/" + ns.Name + "/PolicyViolation"
+		// a protected namespace the selector happens to match is skipped
+		// silently, one it names is reported once
+		if violation, err := t.CC.policyField(projection(ccc, ns.Name, "")); err != nil {
+			if violation.String() == "spec.targetNamespace" && !namesNamespace(ccc.Spec.NamespaceSelector, ns.Name) {
+				log.Debugf("clustercustomconfig %s skips protected namespace %s", ccc.Name, ns.Name)
+				continue
+			}
+			log.Warnf("clustercustomconfig %s skips namespace %s: %v", ccc.Name, ns.Name, err)
+			t.CC.recordOnce(ccc, key, core_v1.EventTypeWarning, "PolicyViolation", fmt.Sprintf("namespace %s skipped: %v", ns.Name, err))
+			continue
+		}
+		t.CC.recordOnce(ccc, key, core_v1.EventTypeWarning, "PolicyViolation", "")
+		matching[ns.Name] = ns
+	}
+	return matching, nil
+}
+
+// namesNamespace tells whether selector selects ns by its name label
+func namesNamespace(selector *meta_v1.LabelSelector, ns string) bool {
+	if selector == nil {
+		return false
+	}
+	if selector.MatchLabels[namespaceNameLabel] == ns {
+		return true
+	}
+	for _, r := range selector.MatchExpressions {
+		if r.Key != namespaceNameLabel || r.Operator != meta_v1.LabelSelectorOpIn {
+			continue
+		}
+		for _, v := range r.Values {
+			if v == ns {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// updateStatus writes status as the status of ccc, unless it did not change
+func (t *CCCHandler) updateStatus(ccc *v1.ClusterCustomConfig, status *v1.ClusterCustomConfigStatus) {
+	if t.CCClient == nil || equality.Semantic.DeepEqual(ccc.Status, *status) {
+		return
+	}
+
+	updated := ccc.DeepCopy()
+	updated.Status = *status
+	_, err := t.CCClient.MtcilV1().ClusterCustomConfigs().UpdateStatus(context.TODO(), updated, meta_v1.UpdateOptions{})
+	if err != nil {
+		log.Errorf("updating status of clustercustomconfig %s: %v", ccc.Name, err)
+	}
+}
+
+// projection returns the CustomConfig setting the key of ccc to value in
+// namespace ns, with the annotations of ccc
+func projection(ccc *v1.ClusterCustomConfig, ns, value string) *v1.CustomConfig {
+	return &v1.CustomConfig{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        ccc.Name,
+			Namespace:   ns,
+			Annotations: ccc.Annotations,
+		},
+		Spec: v1.CustomConfigSpec{
+			Key:             ccc.Spec.Key,
+			Value:           value,
+			ConfigmapName:   ccc.Spec.ConfigmapName,
+			TargetNamespace: ns,
+		},
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"sort"
+	"strings"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	"github.com/pmezard/go-difflib/difflib"
+	log "github.com/sirupsen/logrus"
+	core_v1 "k8s.io/api/core/v1"
+)
+
+// dryRun tells whether the change cc makes to its ConfigMap is only to be
+// reported, either because the operator or cc runs dry
+func (t *CCHandler) dryRun(cc *v1.CustomConfig) bool {
+	return t.DryRun || cc.Spec.DryRun
+}
+
+// dryRunDiff returns the unified diff of the ConfigMap of cc once key is set
+// to value, or removed when remove is set, empty when nothing would change
+func (t *CCHandler) dryRunDiff(cc *v1.CustomConfig, key, value string, remove bool) (string, error) {
+	current, err := t.currentData(cc)
+	if err != nil {
+		return "", err
+	}
+
+	desired := map[string]string{}
+	for k, v := range current {
+		desired[k] = v
+	}
+	if remove {
+		delete(desired, key)
+	} else {
+		desired[key] = value
+	}
+
+	name := TargetNamespace(cc) + "/" + cc.Spec.ConfigmapName
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        renderData(current),
+		B:        renderData(desired),
+		FromFile: "a/" + name,
+		ToFile:   "b/" + name,
+		Context:  3,
+	})
+}
+
+// renderData renders the data of a ConfigMap as the lines of its YAML,
+// multi-line values being rendered as literal blocks
+func renderData(data map[string]string) []string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var lines []string
+	for _, k := range keys {
+		v := data[k]
+		if !strings.Contains(v, "\n") {
+			lines = append(lines, k+": "+v+"\n")
+			continue
+		}
+		lines = append(lines, k+": |\n")
+		for _, l := range strings.Split(strings.TrimSuffix(v, "\n"), "\n") {
+			lines = append(lines, "  "+l+"\n")
+		}
+	}
+	return lines
+}
+
+// reportDryRun records in status and in an event the change cc would make
+// to its ConfigMap
+func (t *CCHandler) reportDryRun(cc *v1.CustomConfig, status *v1.CustomConfigStatus, isActive bool) {
+	key, value, remove, err := t.desiredContent(cc, !isActive)
+	if err != nil {
+		log.Errorf("computing the desired value of customconfig %s/%s: %v", cc.Namespace, cc.Name, err)
+		return
+	}
+	diff, err := t.dryRunDiff(cc, key, value, remove)
+	if err != nil {
+		log.Errorf("computing the dry run of customconfig %s/%s: %v", cc.Namespace, cc.Name, err)
+		return
+	}
+
+	if diff != cc.Status.DryRunDiff && diff != "" {
+		log.Infof("dry run of customconfig %s/%s would change config map %s/%s:\n%s", cc.Namespace, cc.Name, TargetNamespace(cc), cc.Spec.ConfigmapName, diff)
+		recordEvent(t.Recorder, cc, core_v1.EventTypeNormal, "DryRun", "would change config map %s/%s:\n%s", TargetNamespace(cc), cc.Spec.ConfigmapName, diff)
+	}
+	status.DryRunDiff = diff
+}
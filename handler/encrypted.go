@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"fmt"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	log "github.com/sirupsen/logrus"
+	core_v1 "k8s.io/api/core/v1"
+	errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// encrypted tells whether cc writes a decrypted value to a Secret instead
+// of writing its ConfigMap
+func encrypted(cc *v1.CustomConfig) bool {
+	return cc.Spec.EncryptedValue != ""
+}
+
+// syncEncrypted decrypts the value of cc and writes it to its Secret. The
+// plaintext only ever goes to the Secret, the status, revisions and events
+// of cc never hold it.
+func (t *CCHandler) syncEncrypted(cc *v1.CustomConfig, status *v1.CustomConfigStatus, isActive bool) {
+	if reason, message := t.suspension(cc); reason != "" {
+		log.Infof("customconfig %s/%s is suspended: %s", cc.Namespace, cc.Name, message)
+		setCondition(status, v1.ConditionSuspended, core_v1.ConditionTrue, reason, message)
+		t.updateStatus(cc, status)
+		return
+	}
+	clearCondition(status, v1.ConditionSuspended, "Resumed")
+
+	if t.Decrypter == nil {
+		setCondition(status, v1.ConditionDecrypted, core_v1.ConditionFalse, "NoKey", "the operator has no decryption key")
+		t.updateStatus(cc, status)
+		return
+	}
+	value, err := t.Decrypter.Decrypt(cc.Spec.EncryptedValue)
+	if err != nil {
+		log.Warnf("decrypting customconfig %s/%s: %v", cc.Namespace, cc.Name, err)
+		setCondition(status, v1.ConditionDecrypted, core_v1.ConditionFalse, "DecryptionFailed", err.Error())
+		recordEvent(t.Recorder, cc, core_v1.EventTypeWarning, "DecryptionFailed", "value not written: %v", err)
+		t.updateStatus(cc, status)
+		return
+	}
+	setCondition(status, v1.ConditionDecrypted, core_v1.ConditionTrue, "Decrypted", "")
+
+	// the errors of NormalizeValue quote the value, they are not reported
+	if value, err = NormalizeValue(cc.Spec.Type, cc.Spec.Constraints, value); err != nil {
+		message := fmt.Sprintf("the decrypted value is not a valid %s value", cc.Spec.Type)
+		setCondition(status, v1.ConditionValueValid, core_v1.ConditionFalse, "InvalidValue", message)
+		recordEvent(t.Recorder, cc, core_v1.EventTypeWarning, "InvalidValue", "value not written: %s", message)
+		t.updateStatus(cc, status)
+		return
+	}
+	if cc.Spec.Type != "" {
+		setCondition(status, v1.ConditionValueValid, core_v1.ConditionTrue, "Valid", "")
+	} else {
+		clearCondition(status, v1.ConditionValueValid, "Untyped")
+	}
+
+	if t.dryRun(cc) {
+		action := "set"
+		if !isActive {
+			action = "remove"
+		}
+		// a diff would show the value, only the key is reported
+		report := fmt.Sprintf("would %s key %s of secret %s/%s", action, cc.Spec.Key, TargetNamespace(cc), cc.Spec.SecretName)
+		if report != cc.Status.DryRunDiff {
+			recordEvent(t.Recorder, cc, core_v1.EventTypeNormal, "DryRun", "%s", report)
+		}
+		status.DryRunDiff = report
+		t.updateStatus(cc, status)
+		return
+	}
+	status.DryRunDiff = ""
+
+	if _, err = t.writeSecretKey(cc, cc.Spec.Key, value, !isActive); err != nil {
+		log.Errorf("writing secret %s/%s of customconfig %s/%s: %v", TargetNamespace(cc), cc.Spec.SecretName, cc.Namespace, cc.Name, err)
+		return
+	}
+	if isActive {
+		status.AppliedValue = ""
+		status.AppliedGeneration = cc.Generation
+	}
+	t.updateStatus(cc, status)
+}
+
+// writeSecretKey sets key of the Secret targeted by cc to value, creating
+// the Secret if needed, or removes the key when remove is set, deleting the
+// Secret once it has no data left, unless it held keys the operator does
+// not own or the deletion policy of cc retains it. The labels of the target
+// of cc are set along with the key. The returned bool tells whether the key
+// changed.
+func (t *CCHandler) writeSecretKey(cc *v1.CustomConfig, key, value string, remove bool) (bool, error) {
+	ns := TargetNamespace(cc)
+	secrets := t.Client.CoreV1().Secrets(ns)
+
+	secret, err := secrets.Get(cc.Spec.SecretName, meta_v1.GetOptions{})
+	if errors.IsNotFound(err) {
+		if remove {
+			return false, nil
+		}
+		secret = &core_v1.Secret{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      cc.Spec.SecretName,
+				Namespace: ns,
+				Annotations: map[string]string{
+					ownedKeysAnnotation: key,
+				},
+			},
+			Type: core_v1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				key: []byte(value),
+			},
+		}
+		setLabels(&secret.ObjectMeta, targetLabels(cc))
+		if _, err = secrets.Create(secret); err != nil {
+			return false, err
+		}
+		log.Infof("secret %s/%s created", ns, cc.Spec.SecretName)
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	owned := ownedKeys(secret)
+	current, exists := secret.Data[key]
+	if remove {
+		if !exists {
+			return false, nil
+		}
+		ownsAll := true
+		for k := range secret.Data {
+			ownsAll = ownsAll && owned[k]
+		}
+		delete(secret.Data, key)
+		delete(owned, key)
+		setOwnedKeys(secret, owned)
+		if len(secret.Data) == 0 {
+			if ownsAll && cc.Spec.DeletionPolicy != v1.DeletionRetainConfigMap {
+				return true, secrets.Delete(secret.Name, nil)
+			}
+			log.Infof("keeping empty secret %s/%s", ns, secret.Name)
+		}
+	} else {
+		// like in a ConfigMap, a key which already has the value does not
+		// become owned
+		if exists && string(current) == value {
+			if setLabels(&secret.ObjectMeta, targetLabels(cc)) {
+				_, err = secrets.Update(secret)
+			}
+			return false, err
+		}
+		setLabels(&secret.ObjectMeta, targetLabels(cc))
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[key] = []byte(value)
+		owned[key] = true
+		setOwnedKeys(secret, owned)
+	}
+
+	_, err = secrets.Update(secret)
+	return err == nil, err
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// recordEvent records an event about obj, unless there is no recorder. It
+// formats the message itself for go vet to check the calls.
+func recordEvent(recorder record.EventRecorder, obj runtime.Object, eventType, reason, messageFmt string, args ...interface{}) {
+	if recorder == nil || obj == nil {
+		return
+	}
+	recorder.Event(obj, eventType, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// reportOnce records an event about cc with message, unless it is the last
+// one recorded for reason, an empty message only forgetting it. It reports
+// what is found while re-rendering keys several CustomConfigs share, which
+// happens whenever any of them syncs.
+func (t *CCHandler) reportOnce(cc *v1.CustomConfig, eventType, reason, message string) {
+	t.recordOnce(cc, cc.Namespace+"/"+cc.Name+"/"+reason, eventType, reason, message)
+}
+
+// recordOnce records an event about obj with message, unless it is the last
+// one recorded under key, an empty message only forgetting it
+func (t *CCHandler) recordOnce(obj runtime.Object, key, eventType, reason, message string) {
+	t.mu.Lock()
+	last, ok := t.reported[key]
+	if message == "" {
+		delete(t.reported, key)
+	} else {
+		if t.reported == nil {
+			t.reported = map[string]string{}
+		}
+		t.reported[key] = message
+	}
+	t.mu.Unlock()
+
+	if message != "" && (!ok || last != message) {
+		recordEvent(t.Recorder, obj, eventType, reason, "%s", message)
+	}
+}
+
+// forgetReports forgets the messages recorded under the keys with prefix
+func (t *CCHandler) forgetReports(prefix string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key := range t.reported {
+		if strings.HasPrefix(key, prefix) {
+			delete(t.reported, key)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	"github.com/onkarbanerjee/crd-operator/provider"
+	log "github.com/sirupsen/logrus"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultPollInterval is how often an external value is fetched when the
+// CustomConfig does not say
+const defaultPollInterval = time.Minute
+
+// external returns the external source of the value of cc, nil when it has
+// none
+func external(cc *v1.CustomConfig) *v1.ExternalSource {
+	if cc.Spec.ValueFrom == nil {
+		return nil
+	}
+	return cc.Spec.ValueFrom.External
+}
+
+// specValue returns the value cc sets, which is the last one fetched from
+// its external source when it has one
+func (t *CCHandler) specValue(cc *v1.CustomConfig) string {
+	if external(cc) == nil {
+		return cc.Spec.Value
+	}
+	value, _ := t.external.Peek(cc.Namespace + "/" + cc.Name)
+	return value
+}
+
+// fetchExternal fetches the external value of cc, unless the one cached is
+// recent enough, and tells whether there is a value to write. Failures are
+// reported in status and the last value fetched is written meanwhile.
+func (t *CCHandler) fetchExternal(cc *v1.CustomConfig, status *v1.CustomConfigStatus) bool {
+	ext := external(cc)
+	key := cc.Namespace + "/" + cc.Name
+	p, err := t.externalProvider(cc, ext)
+	if err != nil {
+		log.Errorf("customconfig %s has an invalid external source: %v", key, err)
+		setCondition(status, v1.ConditionValueFetched, core_v1.ConditionFalse, "InvalidSource", err.Error())
+		return false
+	}
+
+	interval := defaultPollInterval
+	if ext.PollInterval != nil && ext.PollInterval.Duration > 0 {
+		interval = ext.PollInterval.Duration
+	}
+	_, fetched, err := t.external.Get(context.TODO(), key, p, interval)
+	if fetched && t.Requeue != nil {
+		// the value is polled by syncing cc again once it is stale
+		t.Requeue(key, interval)
+	}
+	if err != nil {
+		if fetched {
+			log.Warnf("fetching the value of customconfig %s from %s: %v", key, p, err)
+			recordEvent(t.Recorder, cc, core_v1.EventTypeWarning, "FetchFailed", "fetching value from %s: %v", p, err)
+		}
+		setCondition(status, v1.ConditionValueFetched, core_v1.ConditionFalse, "FetchFailed", err.Error())
+	} else if fetched {
+		setCondition(status, v1.ConditionValueFetched, core_v1.ConditionTrue, "Fetched", "from "+p.String())
+	}
+	_, ok := t.external.Peek(key)
+	return ok
+}
+
+// externalProvider returns the provider of the external source ext of cc
+func (t *CCHandler) externalProvider(cc *v1.CustomConfig, ext *v1.ExternalSource) (provider.ValueProvider, error) {
+	switch {
+	case ext.File != nil:
+		return &provider.File{Path: ext.File.Path}, nil
+	case ext.HTTP != nil:
+		return &provider.HTTP{URL: ext.HTTP.URL, JSONPath: ext.HTTP.JSONPath, Client: t.HTTPClient}, nil
+	case ext.Vault != nil:
+		vault := &provider.Vault{
+			Address: ext.Vault.Address,
+			Mount:   ext.Vault.Mount,
+			Path:    ext.Vault.Path,
+			Key:     ext.Vault.Key,
+			Version: int(ext.Vault.Version),
+			Client:  t.HTTPClient,
+		}
+		if ref := ext.Vault.TokenSecretRef; ref != nil {
+			// the token is only read when the value is fetched
+			vault.Token = func() (string, error) {
+				return t.secretValue(cc.Namespace, ref)
+			}
+		}
+		return vault, nil
+	}
+	return nil, fmt.Errorf("no file, http or vault source")
+}
+
+// secretValue returns the value of the key of a Secret of namespace ns
+func (t *CCHandler) secretValue(ns string, ref *core_v1.SecretKeySelector) (string, error) {
+	secret, err := t.Client.CoreV1().Secrets(ns).Get(ref.Name, meta_v1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %s/%s has no key %s", ns, ref.Name, ref.Key)
+	}
+	return string(value), nil
+}
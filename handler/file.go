@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	envKeyRe  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	tomlKeyRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// entry is a single key/value pair contributed to a file by a CustomConfig
+type entry struct {
+	key   string
+	value string
+}
+
+// fileFormat returns the format of the file, falling back to the one
+// implied by the extension of its name and to properties otherwise
+func fileFormat(f *v1.FileSpec) v1.FileFormat {
+	if f.Format != "" {
+		return f.Format
+	}
+	switch strings.ToLower(path.Ext(f.Name)) {
+	case ".env":
+		return v1.FormatDotenv
+	case ".json":
+		return v1.FormatJSON
+	case ".yaml", ".yml":
+		return v1.FormatYAML
+	case ".toml":
+		return v1.FormatTOML
+	case ".ini":
+		return v1.FormatINI
+	}
+	return v1.FormatProperties
+}
+
+// fileEntries collects the entries of every CustomConfig contributing to the
+// file self contributes to. Contributors are ordered by namespace/name and
+// when two of them set the same key the first one wins.
+func (t *CCHandler) fileEntries(self *v1.CustomConfig) ([]entry, v1.FileFormat, error) {
+	ns, configmapName, fileName := TargetNamespace(self), self.Spec.ConfigmapName, self.Spec.File.Name
+	ccs, err := t.Lister.List(labels.Everything())
+	if err != nil {
+		return nil, "", err
+	}
+
+	var contributors []*v1.CustomConfig
+	for _, cc := range ccs {
+		if TargetNamespace(cc) == ns && cc.Spec.ConfigmapName == configmapName && cc.Spec.File != nil && cc.Spec.File.Name == fileName && active(cc) {
+			contributors = append(contributors, cc)
+		}
+	}
+	sort.Slice(contributors, func(i, j int) bool {
+		return contributors[i].Namespace+"/"+contributors[i].Name < contributors[j].Namespace+"/"+contributors[j].Name
+	})
+
+	var (
+		entries []entry
+		format  v1.FileFormat
+		seen    = map[string]string{}
+	)
+	for _, cc := range contributors {
+		owner := cc.Namespace + "/" + cc.Name
+		if format == "" {
+			format = fileFormat(cc.Spec.File)
+		} else if f := fileFormat(cc.Spec.File); f != format {
+			log.Warnf("customconfig %s asks for %s format of %s which is already rendered as %s", owner, f, fileName, format)
+		}
+		if prev, ok := seen[cc.Spec.Key]; ok {
+			log.Warnf("customconfig %s sets key %s of %s already set by %s, ignoring it", owner, cc.Spec.Key, fileName, prev)
+			continue
+		}
+		value, err := t.contributedValue(self, cc)
+		if err != nil {
+			log.Warnf("leaving customconfig %s out of %s: %v", owner, fileName, err)
+			continue
+		}
+		seen[cc.Spec.Key] = owner
+		entries = append(entries, entry{key: cc.Spec.Key, value: value})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+	return entries, format, nil
+}
+
+// fileContent re-renders the file key cc contributes to from every
+// CustomConfig currently contributing to it, so that it is called alike for
+// creates, updates and deletes, and returns the key with its content. The
+// key is to be removed once nothing contributes to it.
+func (t *CCHandler) fileContent(cc *v1.CustomConfig) (string, string, bool, error) {
+	name := cc.Spec.File.Name
+
+	entries, format, err := t.fileEntries(cc)
+	if err != nil {
+		return "", "", false, fmt.Errorf("listing contributors of %s: %v", name, err)
+	}
+
+	var content string
+	if len(entries) > 0 {
+		content, err = renderFile(format, entries)
+		if err != nil {
+			return "", "", false, fmt.Errorf("rendering %s: %v", name, err)
+		}
+	}
+
+	return name, content, len(entries) == 0, nil
+}
+
+// renderFile renders the entries, which must be sorted by key, in the given format
+func renderFile(format v1.FileFormat, entries []entry) (string, error) {
+	var buf bytes.Buffer
+	switch format {
+	case v1.FormatProperties:
+		for _, e := range entries {
+			buf.WriteString(escapeProperty(e.key, true))
+			buf.WriteByte('=')
+			buf.WriteString(escapeProperty(e.value, false))
+			buf.WriteByte('\n')
+		}
+	case v1.FormatDotenv:
+		for _, e := range entries {
+			if !envKeyRe.MatchString(e.key) {
+				return "", fmt.Errorf("%q is not a valid dotenv key", e.key)
+			}
+			fmt.Fprintf(&buf, "%s=\"%s\"\n", e.key, escapeDotenv(e.value))
+		}
+	case v1.FormatJSON, v1.FormatYAML:
+		m := make(map[string]string, len(entries))
+		for _, e := range entries {
+			m[e.key] = e.value
+		}
+		if format == v1.FormatYAML {
+			out, err := yaml.Marshal(m)
+			return string(out), err
+		}
+		out, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		buf.Write(out)
+		buf.WriteByte('\n')
+	case v1.FormatTOML:
+		for _, e := range entries {
+			key := e.key
+			if !tomlKeyRe.MatchString(key) {
+				key = `"` + escapeTOML(key) + `"`
+			}
+			fmt.Fprintf(&buf, "%s = \"%s\"\n", key, escapeTOML(e.value))
+		}
+	case v1.FormatINI:
+		for _, e := range entries {
+			if e.key == "" || strings.ContainsAny(e.key, "=[]\r\n;#") || strings.TrimSpace(e.key) != e.key {
+				return "", fmt.Errorf("%q is not a valid ini key", e.key)
+			}
+			fmt.Fprintf(&buf, "%s = %s\n", e.key, escapeINI(e.value))
+		}
+	default:
+		return "", fmt.Errorf("unknown file format %q", format)
+	}
+	return buf.String(), nil
+}
+
+// escapeProperty escapes s the way java.util.Properties.store does
+func escapeProperty(s string, isKey bool) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r == ' ' && (isKey || i == 0):
+			b.WriteString(`\ `)
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\f':
+			b.WriteString(`\f`)
+		case r == '=' || r == ':' || r == '#' || r == '!':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			for _, u := range utf16.Encode([]rune{r}) {
+				fmt.Fprintf(&b, `\u%04X`, u)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// escapeDotenv escapes s for use inside a double quoted dotenv value
+func escapeDotenv(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		`$`, `\$`,
+		"`", "\\`",
+		"\n", `\n`,
+		"\r", `\r`,
+	).Replace(s)
+}
+
+// escapeTOML escapes s for use inside a TOML basic string
+func escapeTOML(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '"':
+			b.WriteString(`\"`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+				continue
+			}
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// escapeINI quotes s when it would otherwise not survive an ini parser
+// unchanged, i.e. when it has surrounding spaces, comment characters,
+// quotes or line breaks
+func escapeINI(s string) string {
+	if s == strings.TrimSpace(s) && !strings.ContainsAny(s, ";#\"\\\r\n") {
+		return s
+	}
+	return `"` + strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\r", `\r`,
+	).Replace(s) + `"`
+}
@@ -0,0 +1,267 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	"github.com/onkarbanerjee/crd-operator/pkg/client/clientset/versioned"
+	log "github.com/sirupsen/logrus"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+)
+
+// FreezeOverrideAnnotation lets the changes to a CustomConfig through the
+// freezes, its value tells why
+const FreezeOverrideAnnotation = "mtcil.com/freeze-override"
+
+// frozenRemovalRecheck is how often a removal held by a freeze is tried
+// again, which notices the freezes ending early
+const frozenRemovalRecheck = time.Minute
+
+// FreezeHandler is the Handler of ConfigFreezes. The changes are held by the
+// CCHandler, which is asked to sync every CustomConfig whenever a freeze
+// starts, ends or changes, releasing the changes it held.
+type FreezeHandler struct {
+	CC *CCHandler
+	// CCClient is used to write the status of the ConfigFreezes
+	CCClient versioned.Interface
+	// Recorder records the start and end of the freezes
+	Recorder record.EventRecorder
+	// Enqueue syncs the CustomConfig with the given namespace/name key
+	Enqueue func(key string)
+	// EnqueueClusterConfigs syncs every ClusterCustomConfig, whose changes
+	// to the namespaces frozen are held as well
+	EnqueueClusterConfigs func()
+	// Requeue syncs a ConfigFreeze again after a delay, at its transitions
+	Requeue func(key string, delay time.Duration)
+}
+
+// Init handles any handler initialization
+func (t *FreezeHandler) Init() error {
+	log.Info("FreezeHandler.Init")
+	return nil
+}
+
+// ObjectCreated is called when an object is created
+func (t *FreezeHandler) ObjectCreated(obj interface{}) {
+	log.Info("FreezeHandler.ObjectCreated")
+	if f, ok := obj.(*v1.ConfigFreeze); ok {
+		t.sync(f)
+	}
+}
+
+// ObjectDeleted is called when an object is deleted, the changes it held
+// are released
+func (t *FreezeHandler) ObjectDeleted(obj interface{}) {
+	log.Info("FreezeHandler.ObjectDeleted")
+	if f, ok := obj.(*v1.ConfigFreeze); ok {
+		log.Infof("configfreeze %s deleted, releasing the changes it held", f.Name)
+		t.enqueueCustomConfigs()
+	}
+}
+
+// ObjectUpdated is called when an object is updated
+func (t *FreezeHandler) ObjectUpdated(obj interface{}) {
+	log.Info("FreezeHandler.ObjectUpdated")
+	if f, ok := obj.(*v1.ConfigFreeze); ok {
+		t.sync(f)
+	}
+}
+
+// sync records whether f is in force, has every CustomConfig synced to
+// hold or release its changes and wakes up at the next transition of f
+func (t *FreezeHandler) sync(f *v1.ConfigFreeze) {
+	status := f.Status.DeepCopy()
+	frozen, next, err := freezeActivity(f, time.Now())
+	status.NextTransitionTime = nil
+	switch {
+	case err != nil:
+		log.Errorf("scheduling configfreeze %s: %v", f.Name, err)
+		setFreezeCondition(status, core_v1.ConditionFalse, "InvalidWindow", err.Error())
+	case frozen:
+		setFreezeCondition(status, core_v1.ConditionTrue, "Frozen", f.Spec.Reason)
+	default:
+		setFreezeCondition(status, core_v1.ConditionFalse, "Thawed", "")
+	}
+	if next != nil {
+		status.NextTransitionTime = &meta_v1.Time{Time: *next}
+		if t.Requeue != nil {
+			t.Requeue(f.Name, time.Until(*next)+time.Second)
+		}
+	}
+
+	if wasFrozen := isFrozen(f.Status); frozen != wasFrozen {
+		if frozen {
+			log.Infof("configfreeze %s started", f.Name)
+			recordEvent(t.Recorder, f, core_v1.EventTypeNormal, "FreezeStarted", "holding the changes to the custom configs")
+		} else if err == nil {
+			log.Infof("configfreeze %s ended", f.Name)
+			recordEvent(t.Recorder, f, core_v1.EventTypeNormal, "FreezeEnded", "releasing the changes held")
+		}
+	}
+	// the spec may select other namespaces, every CustomConfig and
+	// ClusterCustomConfig is synced
+	t.enqueueCustomConfigs()
+
+	if t.CCClient == nil || equality.Semantic.DeepEqual(f.Status, *status) {
+		return
+	}
+	updated := f.DeepCopy()
+	updated.Status = *status
+	if _, err = t.CCClient.MtcilV1().ConfigFreezes().UpdateStatus(context.TODO(), updated, meta_v1.UpdateOptions{}); err != nil {
+		log.Errorf("updating status of configfreeze %s: %v", f.Name, err)
+	}
+}
+
+// enqueueCustomConfigs has every CustomConfig and ClusterCustomConfig synced
+func (t *FreezeHandler) enqueueCustomConfigs() {
+	if t.EnqueueClusterConfigs != nil {
+		t.EnqueueClusterConfigs()
+	}
+	if t.Enqueue == nil || t.CC == nil || t.CC.Lister == nil {
+		return
+	}
+	ccs, err := t.CC.Lister.List(labels.Everything())
+	if err != nil {
+		log.Error("error is", err)
+		return
+	}
+	for _, cc := range ccs {
+		t.Enqueue(cc.Namespace + "/" + cc.Name)
+	}
+}
+
+// freezeActivity tells whether now is within a window of f, and when that
+// changes next, which is nil when it never does. Overlapping windows make a
+// single freeze, which ends with the last of them.
+func freezeActivity(f *v1.ConfigFreeze, now time.Time) (bool, *time.Time, error) {
+	frozen := false
+	var end, start *time.Time
+	for i, w := range f.Spec.Windows {
+		var inWindow bool
+		var transition time.Time
+		switch {
+		case w.Schedule != nil:
+			var err error
+			if inWindow, transition, err = scheduled(w.Schedule, now); err != nil {
+				return false, nil, fmt.Errorf("window %d: %v", i, err)
+			}
+		case w.End != nil:
+			if w.Start != nil && !w.End.After(w.Start.Time) {
+				return false, nil, fmt.Errorf("window %d ends before it starts", i)
+			}
+			if !now.Before(w.End.Time) {
+				continue
+			}
+			inWindow = w.Start == nil || !now.Before(w.Start.Time)
+			transition = w.End.Time
+			if !inWindow {
+				transition = w.Start.Time
+			}
+		default:
+			return false, nil, fmt.Errorf("window %d has neither an end nor a schedule", i)
+		}
+
+		at := transition
+		if inWindow {
+			frozen = true
+			if end == nil || at.After(*end) {
+				end = &at
+			}
+		} else if start == nil || at.Before(*start) {
+			start = &at
+		}
+	}
+	if frozen {
+		return true, end, nil
+	}
+	return false, start, nil
+}
+
+// isFrozen tells whether status records a freeze in force
+func isFrozen(status v1.ConfigFreezeStatus) bool {
+	for _, c := range status.Conditions {
+		if c.Type == v1.ConditionActive {
+			return c.Status == core_v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// setFreezeCondition sets the Active condition of a ConfigFreeze
+func setFreezeCondition(status *v1.ConfigFreezeStatus, conditionStatus core_v1.ConditionStatus, reason, message string) {
+	ccStatus := &v1.CustomConfigStatus{Conditions: status.Conditions}
+	setCondition(ccStatus, v1.ConditionActive, conditionStatus, reason, message)
+	status.Conditions = ccStatus.Conditions
+}
+
+// activeFreeze returns the ConfigFreeze in force over the target namespace
+// of cc, and when it ends, nil when there is none
+func (t *CCHandler) activeFreeze(cc *v1.CustomConfig) (*v1.ConfigFreeze, time.Time) {
+	if t.Freezes == nil {
+		return nil, time.Time{}
+	}
+	freezes, err := t.Freezes.List(labels.Everything())
+	if err != nil {
+		log.Error("error is", err)
+		return nil, time.Time{}
+	}
+
+	var nsLabels labels.Set
+	if t.Namespaces != nil {
+		if ns, err := t.Namespaces.Get(TargetNamespace(cc)); err == nil {
+			nsLabels = ns.Labels
+		}
+	}
+	now := time.Now()
+	var freeze *v1.ConfigFreeze
+	var end time.Time
+	for _, f := range freezes {
+		if f.Spec.NamespaceSelector != nil {
+			selector, err := meta_v1.LabelSelectorAsSelector(f.Spec.NamespaceSelector)
+			if err != nil || !selector.Matches(nsLabels) {
+				continue
+			}
+		}
+		// a freeze in force always ends
+		frozen, next, err := freezeActivity(f, now)
+		if err != nil || !frozen || next == nil {
+			continue
+		}
+		if freeze == nil || next.After(end) {
+			freeze, end = f, *next
+		}
+	}
+	return freeze, end
+}
+
+// frozen tells whether a ConfigFreeze holds the changes to cc, which its
+// emergency override annotation lets through
+func (t *CCHandler) frozen(cc *v1.CustomConfig) bool {
+	if cc.Annotations[FreezeOverrideAnnotation] != "" {
+		return false
+	}
+	f, _ := t.activeFreeze(cc)
+	return f != nil
+}
+
+// removalHeld tells whether a freeze holds the removal of the key of the
+// deleted cc, which the emergency override annotation of cc lets through,
+// and when to try it again
+func (t *CCHandler) removalHeld(cc *v1.CustomConfig) (time.Duration, bool) {
+	f, end := t.activeFreeze(cc)
+	if f == nil || cc.Annotations[FreezeOverrideAnnotation] != "" {
+		return 0, false
+	}
+	log.Infof("removal of key %s of config map %s/%s held by configfreeze %s until %s", cc.Spec.Key, TargetNamespace(cc), cc.Spec.ConfigmapName, f.Name, end.Format(time.RFC3339))
+	retry := time.Until(end) + time.Second
+	if retry > frozenRemovalRecheck {
+		retry = frozenRemovalRecheck
+	}
+	return retry, true
+}
@@ -1,14 +1,26 @@
 package handler
 
 import (
+	"crypto/ed25519"
+	"fmt"
+	"net/http"
 	"os"
+	"sync"
+	"time"
 
+	"github.com/onkarbanerjee/crd-operator/encryption"
 	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	"github.com/onkarbanerjee/crd-operator/pkg/client/clientset/versioned"
+	listers "github.com/onkarbanerjee/crd-operator/pkg/client/listers/customconfig/v1"
+	"github.com/onkarbanerjee/crd-operator/provider"
+	"github.com/onkarbanerjee/crd-operator/workload"
 	log "github.com/sirupsen/logrus"
 	core_v1 "k8s.io/api/core/v1"
 	errors "k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
 )
 
 // Handler interface contains the methods that are required
@@ -22,6 +34,106 @@ type Handler interface {
 // CCHandler is a sample implementation of Handler
 type CCHandler struct {
 	Client kubernetes.Interface
+	// CCClient is used to write the status of the CustomConfigs
+	CCClient versioned.Interface
+	// Lister gives access to every CustomConfig, which is needed when
+	// several of them contribute to the same ConfigMap key
+	Lister listers.CustomConfigLister
+	// Restarter rolls the workloads consuming a ConfigMap after it changed,
+	// no workload is restarted when it is nil
+	Restarter *workload.Restarter
+	// RestartPolicy applies to the CustomConfigs not setting their own
+	RestartPolicy v1.RestartPolicy
+	// Overrides lists the CustomConfigOverrides, none applies when it is nil
+	Overrides listers.CustomConfigOverrideLister
+	// Schemas lists the ConfigSchemas the values are validated against,
+	// none is when it is nil
+	Schemas listers.ConfigSchemaLister
+	// Policy protects namespaces, ConfigMaps and keys from the CustomConfigs
+	Policy v1.CustomConfigPolicySpec
+	// Policies lists the CustomConfigPolicies adding to Policy, none does
+	// when it is nil
+	Policies listers.CustomConfigPolicyLister
+	// Freezes lists the ConfigFreezes, none holds the changes when it is nil
+	Freezes listers.ConfigFreezeLister
+	// Namespaces lists the namespaces the ConfigFreezes select, only the
+	// freezes without a selector apply when it is nil
+	Namespaces corelisters.NamespaceLister
+	// Recorder records the events of the CustomConfigs, none is when it is nil
+	Recorder record.EventRecorder
+	// DryRun makes every CustomConfig only report the change it would make
+	DryRun bool
+	// Paused tells whether the operator is paused, it is not when nil
+	Paused func() bool
+	// Requeue syncs a CustomConfig again after a delay, which scheduled
+	// CustomConfigs need to be woken up at their transitions
+	Requeue func(key string, delay time.Duration)
+	// Stop is closed when the operator stops, which stops watching the
+	// rollouts in the background
+	Stop <-chan struct{}
+	// RequeueDeleted handles the deletion of a CustomConfig again after a
+	// delay, which the removals held by a freeze need. They are not held
+	// when it is nil.
+	RequeueDeleted func(key string, delay time.Duration)
+
+	// Decrypter decrypts the encrypted values, which are not written when
+	// it is nil
+	Decrypter *encryption.Key
+	// TrustedKeys returns the public keys the CustomConfigs of a namespace
+	// must be signed with, and whether they must be signed at all, which
+	// they need not be when it is nil
+	TrustedKeys func(ns string) ([]ed25519.PublicKey, bool)
+	// Defaults returns the defaults the webhook fills in the CustomConfigs
+	// of a namespace, after they were signed, none when it is nil
+	Defaults func(ns string) v1.CustomConfigDefaultsSpec
+	// AuthorizeWrites only writes the ConfigMaps and Secrets the user who
+	// last changed a CustomConfig could write directly
+	AuthorizeWrites bool
+	// RequiresApproval tells whether the CustomConfig changes in a namespace
+	// must be approved, which they need not be when it is nil
+	RequiresApproval func(ns string) bool
+	// ApprovalsRecorded tells whether the webhook records who changes and
+	// approves the CustomConfigs. Anyone could set these annotations
+	// otherwise, so every change in the namespaces requiring approval is
+	// held.
+	ApprovalsRecorded bool
+	// HTTPClient fetches the values of the HTTP and Vault sources, a client
+	// with a 10s timeout when nil
+	HTTPClient *http.Client
+
+	mu      sync.Mutex
+	wakeups map[string]time.Time
+	// reported holds the last message recorded by recordOnce, by
+	// namespace/name/reason for the CustomConfigs and by
+	// clustercustomconfig/name/namespace/reason for the ClusterCustomConfigs
+	reported map[string]string
+	// deferred holds the removals of the resources deleted while the
+	// operator is paused, by kind/namespace/name
+	deferred map[string]func()
+	// gates holds the rollouts watched in the background, by namespace/name
+	// of their ConfigMap
+	gates map[string]*rolloutGate
+	// external caches the values fetched from the external sources
+	external provider.Cache
+}
+
+// TargetNamespace returns the namespace of the ConfigMap cc writes to, which
+// defaults to the namespace of the operator
+func TargetNamespace(cc *v1.CustomConfig) string {
+	if cc.Spec.TargetNamespace != "" {
+		return cc.Spec.TargetNamespace
+	}
+	ns := operatorNamespace()
+	log.Info("ns is", ns)
+	return ns
+}
+
+// operatorNamespace returns the namespace the operator runs in
+func operatorNamespace() string {
+	if ns := os.Getenv("NAMESPACE"); ns != "" {
+		return ns
+	}
+	return "default"
 }
 
 // Init handles any handler initialization
@@ -36,45 +148,13 @@ func (t *CCHandler) ObjectCreated(obj interface{}) {
 
 	cc, ok1 := obj.(*v1.CustomConfig)
 	log.Info("ok is", ok1)
-
-	log.Info("cc is ", cc.Spec.Key, cc.Spec.Value, cc.Spec.ConfigmapName)
-
-	ns := os.Getenv("NAMESPACE")
-	log.Info("ns is", ns)
-	if ns == "" {
-		ns = "default"
-	}
-
-	cm := core_v1.ConfigMap{
-		TypeMeta: meta_v1.TypeMeta{
-			Kind:       "ConfigMap",
-			APIVersion: "v1",
-		},
-		ObjectMeta: meta_v1.ObjectMeta{
-			Name:      cc.Spec.ConfigmapName,
-			Namespace: ns,
-		},
-		Data: map[string]string{
-			cc.Spec.Key: cc.Spec.Value,
-		},
-	}
-
-	// if t.Client == nil {
-	// 	log.Info("handler doesnt have a k8s client, returning")
-	// 	return
-	// }
-	_, err := t.Client.CoreV1().ConfigMaps(ns).Create(&cm)
-	if err != nil {
-		if errors.IsAlreadyExists(err) || errors.IsConflict(err) {
-			// Resource already exists. Carry on.
-			log.Info("config map is already exist")
-			return
-		}
-		log.Error("error is", err)
+	if !ok1 {
 		return
 	}
 
-	log.Info("config map created")
+	log.Info("cc is ", cc.Spec.Key, cc.Spec.Value, cc.Spec.ConfigmapName)
+
+	t.sync(cc)
 }
 
 // ObjectDeleted is called when an object is deleted
@@ -82,39 +162,66 @@ func (t *CCHandler) ObjectDeleted(obj interface{}) {
 	log.Info("CCHandler.ObjectDeleted")
 	cc, ok1 := obj.(*v1.CustomConfig)
 	log.Info("ok is", ok1)
+	if !ok1 {
+		return
+	}
 
 	log.Info("cc is ", cc.Spec.Key, cc.Spec.Value, cc.Spec.ConfigmapName)
 
-	ns := os.Getenv("NAMESPACE")
-	log.Info("ns is", ns)
-	if ns == "" {
-		ns = "default"
+	// a deletion requeued by a freeze is superseded by a CustomConfig
+	// created again since
+	if t.Lister != nil {
+		if _, err := t.Lister.CustomConfigs(cc.Namespace).Get(cc.Name); err == nil {
+			log.Infof("customconfig %s/%s was created again, not removing its key", cc.Namespace, cc.Name)
+			return
+		}
 	}
 
-	cm, err := t.Client.CoreV1().ConfigMaps(ns).Get(cc.Spec.ConfigmapName, meta_v1.GetOptions{})
-	if err != nil {
-		log.Error("error is", err)
-		return
+	t.mu.Lock()
+	delete(t.wakeups, cc.Namespace+"/"+cc.Name)
+	for _, reason := range []string{"InvalidFragment", "MergeConflict"} {
+		delete(t.reported, cc.Namespace+"/"+cc.Name+"/"+reason)
 	}
+	t.mu.Unlock()
+	t.external.Forget(cc.Namespace + "/" + cc.Name)
 
-	delete(cm.Data, cc.Spec.Key)
-
-	log.Info("len of data is", len(cm.Data))
-	if len(cm.Data) == 0 {
-		err = t.Client.CoreV1().ConfigMaps(ns).Delete(cm.Name, nil)
-		if err != nil {
+	if cc.Spec.DeletionPolicy == v1.DeletionRetain {
+		log.Infof("customconfig %s/%s deleted, retaining its key in config map %s/%s", cc.Namespace, cc.Name, TargetNamespace(cc), cc.Spec.ConfigmapName)
+		return
+	}
+	// a suspended CustomConfig leaves its ConfigMap as it is, deleted or not
+	if cc.Spec.Suspend {
+		log.Infof("suspended customconfig %s/%s deleted, retaining its key in config map %s/%s", cc.Namespace, cc.Name, TargetNamespace(cc), cc.Spec.ConfigmapName)
+		return
+	}
+	if t.paused() {
+		t.deferDelete("customconfig/"+cc.Namespace+"/"+cc.Name, func() { t.ObjectDeleted(cc) })
+		return
+	}
+	// what the policy protects is not written, which removing the key is
+	if err := t.policyViolation(cc); err != nil {
+		log.Infof("customconfig %s/%s deleted, not removing its key: %v", cc.Namespace, cc.Name, err)
+		return
+	}
+	if foreignTarget(cc) {
+		if _, _, err := t.authorizeWrite(cc); err != nil {
+			log.Infof("customconfig %s/%s deleted, not removing its key: %v", cc.Namespace, cc.Name, err)
+			return
+		}
+	}
+	if retry, held := t.removalHeld(cc); held && t.RequeueDeleted != nil {
+		t.RequeueDeleted(cc.Namespace+"/"+cc.Name, retry)
+		return
+	}
+	if encrypted(cc) {
+		if _, err := t.writeSecretKey(cc, cc.Spec.Key, "", true); err != nil {
 			log.Error("error is", err)
 		}
 		return
 	}
-
-	_, err = t.Client.CoreV1().ConfigMaps(ns).Update(cm)
-
-	if err != nil {
+	if _, err := t.apply(cc, true); err != nil {
 		log.Error("error is", err)
-		return
 	}
-
 }
 
 // ObjectUpdated is called when an object is updated
@@ -123,26 +230,430 @@ func (t *CCHandler) ObjectUpdated(obj interface{}) {
 
 	cc, ok1 := obj.(*v1.CustomConfig)
 	log.Info("ok is", ok1)
+	if !ok1 {
+		return
+	}
 
 	log.Info("cc is ", cc.Spec.Key, cc.Spec.Value, cc.Spec.ConfigmapName)
 
-	ns := os.Getenv("NAMESPACE")
-	log.Info("ns is", ns)
-	if ns == "" {
-		ns = "default"
+	t.sync(cc)
+}
+
+// sync writes the desired value of cc to its ConfigMap and records what was
+// applied in the status of cc
+func (t *CCHandler) sync(cc *v1.CustomConfig) {
+	status := cc.Status.DeepCopy()
+	isActive, next, err := activity(cc, time.Now())
+	if err != nil {
+		log.Errorf("scheduling customconfig %s/%s: %v", cc.Namespace, cc.Name, err)
+		setCondition(status, v1.ConditionActive, core_v1.ConditionFalse, "InvalidSchedule", err.Error())
+		t.updateStatus(cc, status)
+		return
+	}
+	status.Override = ""
+	if o := t.activeOverride(cc); o != nil {
+		status.Override = o.Name
+	}
+	status.NextTransitionTime = nil
+	if next != nil {
+		status.NextTransitionTime = &meta_v1.Time{Time: *next}
+		t.wakeUp(cc, *next)
+	}
+	if isActive {
+		setCondition(status, v1.ConditionActive, core_v1.ConditionTrue, "Active", "")
+	} else {
+		setCondition(status, v1.ConditionActive, core_v1.ConditionFalse, "Inactive", "outside of the activity window or schedule")
+	}
+
+	// nothing the policy of the operator protects is written
+	if err := t.policyViolation(cc); err != nil {
+		log.Warnf("not applying customconfig %s/%s: %v", cc.Namespace, cc.Name, err)
+		if c := getCondition(cc.Status, v1.ConditionPolicyViolation); c == nil || c.Status != core_v1.ConditionTrue || c.Message != err.Error() {
+			recordEvent(t.Recorder, cc, core_v1.EventTypeWarning, "PolicyViolation", "not applied: %v", err)
+		}
+		setCondition(status, v1.ConditionPolicyViolation, core_v1.ConditionTrue, "Protected", err.Error())
+		t.updateStatus(cc, status)
+		return
+	}
+	clearCondition(status, v1.ConditionPolicyViolation, "Compliant")
+
+	// only the signed CustomConfigs of the namespaces requiring it are
+	// applied, removing the key of an inactive one needs no signature
+	required, reason, err := t.verifySignature(cc)
+	switch {
+	case err != nil && isActive:
+		log.Warnf("not applying customconfig %s/%s: %v", cc.Namespace, cc.Name, err)
+		setCondition(status, v1.ConditionVerified, core_v1.ConditionFalse, reason, err.Error())
+		recordEvent(t.Recorder, cc, core_v1.EventTypeWarning, reason, "not applied: %v", err)
+		t.updateStatus(cc, status)
+		return
+	case err != nil:
+		setCondition(status, v1.ConditionVerified, core_v1.ConditionFalse, reason, err.Error())
+	case required:
+		setCondition(status, v1.ConditionVerified, core_v1.ConditionTrue, "Verified", "")
+	default:
+		clearCondition(status, v1.ConditionVerified, "NotRequired")
+	}
+
+	// in the namespaces requiring approval, the last approved value stays
+	// applied until the current generation is approved
+	switch {
+	case t.pendingApproval(cc):
+		message := fmt.Sprintf("generation %d awaits approval", cc.Generation)
+		if !t.ApprovalsRecorded {
+			message = fmt.Sprintf("generation %d is held, approvals are not recorded without the webhook", cc.Generation)
+		} else if author := cc.Annotations[ChangedByAnnotation]; author != "" {
+			message += " by a user other than " + author
+		}
+		if c := getCondition(cc.Status, v1.ConditionApproved); c == nil || c.Reason != "PendingApproval" || c.Message != message {
+			recordEvent(t.Recorder, cc, core_v1.EventTypeNormal, "PendingApproval", "%s", message)
+		}
+		setCondition(status, v1.ConditionApproved, core_v1.ConditionFalse, "PendingApproval", message)
+		t.updateStatus(cc, status)
+		return
+	case t.requiresApproval(cc):
+		status.ApprovedGeneration, status.ApprovedBy = Approval(cc)
+		setCondition(status, v1.ConditionApproved, core_v1.ConditionTrue, "Approved", "approved by "+status.ApprovedBy)
+	default:
+		clearCondition(status, v1.ConditionApproved, "NotRequired")
+	}
+
+	// during a freeze the values last applied stay written, unless the
+	// emergency override annotation lets the changes through
+	if f, end := t.activeFreeze(cc); f != nil {
+		if override := cc.Annotations[FreezeOverrideAnnotation]; override == "" {
+			message := fmt.Sprintf("changes held by configfreeze %s until %s", f.Name, end.Format(time.RFC3339))
+			if c := getCondition(cc.Status, v1.ConditionFrozen); c == nil || c.Status != core_v1.ConditionTrue || c.Message != message {
+				recordEvent(t.Recorder, cc, core_v1.EventTypeNormal, "Frozen", "%s", message)
+			}
+			setCondition(status, v1.ConditionFrozen, core_v1.ConditionTrue, "Frozen", message)
+			t.updateStatus(cc, status)
+			return
+		} else if c := getCondition(cc.Status, v1.ConditionFrozen); c == nil || c.Reason != "EmergencyOverride" {
+			log.Warnf("customconfig %s/%s overrides configfreeze %s: %s", cc.Namespace, cc.Name, f.Name, override)
+			recordEvent(t.Recorder, cc, core_v1.EventTypeWarning, "EmergencyOverride", "configfreeze %s overridden: %s", f.Name, override)
+		}
+		setCondition(status, v1.ConditionFrozen, core_v1.ConditionFalse, "EmergencyOverride", fmt.Sprintf("configfreeze %s overridden", f.Name))
+	} else {
+		clearCondition(status, v1.ConditionFrozen, "NotFrozen")
+	}
+
+	// the operator does not write what the author of cc could not
+	if t.AuthorizeWrites || foreignTarget(cc) {
+		message, reason, err := t.authorizeWrite(cc)
+		if err != nil {
+			if reason == "" {
+				log.Error("error is", err)
+				return
+			}
+			log.Warnf("not applying customconfig %s/%s: %v", cc.Namespace, cc.Name, err)
+			if c := getCondition(cc.Status, v1.ConditionAuthorized); c == nil || c.Reason != reason || c.Message != err.Error() {
+				recordEvent(t.Recorder, cc, core_v1.EventTypeWarning, reason, "not applied: %v", err)
+			}
+			setCondition(status, v1.ConditionAuthorized, core_v1.ConditionFalse, reason, err.Error())
+			t.updateStatus(cc, status)
+			return
+		}
+		setCondition(status, v1.ConditionAuthorized, core_v1.ConditionTrue, "Authorized", message)
+	} else {
+		clearCondition(status, v1.ConditionAuthorized, "NotChecked")
+	}
+
+	if encrypted(cc) {
+		// the plaintext is never kept, there are no revisions to go back to
+		if cc.Spec.RollbackTo != nil {
+			message := "spec.rollbackTo is not carried out for an encrypted value, which keeps no revisions"
+			if c := getCondition(cc.Status, v1.ConditionRolledBack); c == nil || c.Reason != "RollbackUnsupported" {
+				log.Warnf("customconfig %s/%s: %s", cc.Namespace, cc.Name, message)
+				recordEvent(t.Recorder, cc, core_v1.EventTypeWarning, "RollbackUnsupported", "%s", message)
+			}
+			setCondition(status, v1.ConditionRolledBack, core_v1.ConditionFalse, "RollbackUnsupported", message)
+		}
+		t.syncEncrypted(cc, status, isActive)
+		return
+	}
+
+	// an external value is only written once it could be fetched
+	if external(cc) != nil {
+		if !t.fetchExternal(cc, status) {
+			t.updateStatus(cc, status)
+			return
+		}
+	} else {
+		clearCondition(status, v1.ConditionValueFetched, "NoSource")
+	}
+
+	// an invalid value is reported instead of being written, the key of an
+	// inactive CustomConfig is removed whatever its value
+	if isActive {
+		if _, reason, err := t.validatedValue(cc); err != nil {
+			if reason == "" {
+				log.Error("error is", err)
+				return
+			}
+			log.Warnf("customconfig %s/%s has an invalid value: %v", cc.Namespace, cc.Name, err)
+			setCondition(status, v1.ConditionValueValid, core_v1.ConditionFalse, reason, err.Error())
+			recordEvent(t.Recorder, cc, core_v1.EventTypeWarning, reason, "value not written: %v", err)
+			t.updateStatus(cc, status)
+			return
+		}
+		if cc.Spec.Type != "" || t.hasSchema(cc) {
+			setCondition(status, v1.ConditionValueValid, core_v1.ConditionTrue, "Valid", "")
+		} else {
+			clearCondition(status, v1.ConditionValueValid, "Untyped")
+		}
+	}
+
+	if reason, message := t.suspension(cc); reason != "" {
+		log.Infof("customconfig %s/%s is suspended: %s", cc.Namespace, cc.Name, message)
+		setCondition(status, v1.ConditionSuspended, core_v1.ConditionTrue, reason, message)
+		t.reportDrift(cc, status, isActive)
+		t.updateStatus(cc, status)
+		return
+	}
+	clearCondition(status, v1.ConditionSuspended, "Resumed")
+	clearCondition(status, v1.ConditionDrifted, "InSync")
+
+	if t.dryRun(cc) {
+		t.reportDryRun(cc, status, isActive)
+		t.updateStatus(cc, status)
+		return
+	}
+	status.DryRunDiff = ""
+
+	if cc.Spec.RollbackTo != nil {
+		// the operator cannot sign the spec it would roll back
+		if required {
+			message := fmt.Sprintf("spec.rollbackTo is not carried out in a namespace requiring signatures, set the value of revision %d and sign it instead", *cc.Spec.RollbackTo)
+			if c := getCondition(cc.Status, v1.ConditionRolledBack); c == nil || c.Reason != "RollbackRefused" || c.Message != message {
+				log.Warnf("customconfig %s/%s: %s", cc.Namespace, cc.Name, message)
+				recordEvent(t.Recorder, cc, core_v1.EventTypeWarning, "RollbackRefused", "%s", message)
+			}
+			setCondition(status, v1.ConditionRolledBack, core_v1.ConditionFalse, "RollbackRefused", message)
+			t.updateStatus(cc, status)
+			return
+		}
+		rolledBack, err := t.rollBackTo(cc, status)
+		if err != nil {
+			log.Errorf("rolling back customconfig %s/%s: %v", cc.Namespace, cc.Name, err)
+			return
+		}
+		// the update rolling back syncs cc again
+		if !rolledBack {
+			t.updateStatus(cc, status)
+		}
+		return
 	}
 
-	cm, err := t.Client.CoreV1().ConfigMaps(ns).Get(cc.Spec.ConfigmapName, meta_v1.GetOptions{})
+	changed, err := t.apply(cc, !isActive)
 	if err != nil {
 		log.Error("error is", err)
 		return
 	}
 
-	cm.Data[cc.Spec.Key] = cc.Spec.Value
-	_, err = t.Client.CoreV1().ConfigMaps(ns).Update(cm)
+	applied := t.specValue(cc)
+	if normalized, err := NormalizeValue(cc.Spec.Type, cc.Spec.Constraints, applied); err == nil {
+		applied = normalized
+	}
+	// an external value changes without the generation of cc changing
+	externalChange := external(cc) != nil && applied != cc.Status.AppliedValue
+	if isActive && cc.Generation != cc.Status.RolledBackGeneration && (cc.Generation != cc.Status.AppliedGeneration || externalChange) {
+		previous, hadPrevious := cc.Status.AppliedValue, cc.Status.AppliedGeneration != 0
+		status.AppliedValue = applied
+		status.AppliedGeneration = cc.Generation
+		if err = t.recordRevision(cc, applied, status); err != nil {
+			log.Errorf("recording revision of customconfig %s/%s: %v", cc.Namespace, cc.Name, err)
+		}
+		if cc.Spec.Rollout != nil {
+			setCondition(status, v1.ConditionRolledBack, core_v1.ConditionFalse, "RolloutStarted", "")
+			if changed && hadPrevious {
+				t.gateRollout(cc, previous)
+			}
+		}
+	}
+	t.updateStatus(cc, status)
+}
+
+// wakeUp syncs cc again at the given time, unless that is already planned
+func (t *CCHandler) wakeUp(cc *v1.CustomConfig, at time.Time) {
+	if t.Requeue == nil {
+		return
+	}
+
+	key := cc.Namespace + "/" + cc.Name
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if planned, ok := t.wakeups[key]; ok && planned.Equal(at) {
+		return
+	}
+	if t.wakeups == nil {
+		t.wakeups = map[string]time.Time{}
+	}
+	t.wakeups[key] = at
+	// the queue never hands the key out early, the second makes sure the
+	// transition is over by then
+	t.Requeue(key, time.Until(at)+time.Second)
+}
 
+// apply writes the desired value of cc to its ConfigMap, or removes it when
+// remove is set, and tells whether the ConfigMap changed
+func (t *CCHandler) apply(cc *v1.CustomConfig, remove bool) (bool, error) {
+	// an empty name is left to the defaulting webhook, which may not run
+	if cc.Spec.ConfigmapName == "" {
+		return false, fmt.Errorf("customconfig %s/%s names no config map", cc.Namespace, cc.Name)
+	}
+	key, value, remove, err := t.desiredContent(cc, remove)
 	if err != nil {
-		log.Error("error is", err)
+		return false, err
+	}
+	return t.writeKey(cc, key, value, remove)
+}
+
+// desiredContent returns the ConfigMap key cc writes to and the value it is
+// to have, or whether it is to be removed. A key is removed whatever its
+// value, which is not validated then.
+func (t *CCHandler) desiredContent(cc *v1.CustomConfig, remove bool) (string, string, bool, error) {
+	switch {
+	case cc.Spec.File != nil:
+		return t.fileContent(cc)
+	case cc.Spec.Strategy == v1.StrategyMerge:
+		return t.mergedContent(cc)
+	case remove:
+		return cc.Spec.Key, "", true, nil
+	}
+	value, _, err := t.validatedValue(cc)
+	return cc.Spec.Key, value, remove, err
+}
+
+// desiredValue returns the value cc is to write, which is the one of its
+// active override if any, else the one of its spec unless that generation
+// was rolled back, awaits approval or is frozen
+func (t *CCHandler) desiredValue(cc *v1.CustomConfig) string {
+	if o := t.activeOverride(cc); o != nil {
+		return o.Spec.Value
+	}
+	if cc.Status.RolledBackGeneration != 0 && cc.Status.RolledBackGeneration == cc.Generation {
+		return cc.Status.AppliedValue
+	}
+	if cc.Status.AppliedGeneration != 0 && (t.pendingApproval(cc) || t.frozen(cc)) {
+		return cc.Status.AppliedValue
+	}
+	return t.specValue(cc)
+}
+
+// writeKey sets key of the ConfigMap targeted by cc to value, creating the
+// ConfigMap if needed, or removes the key when remove is set, deleting the
+// ConfigMap once it has no data left, unless it held keys the operator does
+// not own or the deletion policy of cc retains it. The labels of the target
+// of cc are set along with the key. Nothing is written when the key is
+// already in the wanted state, but for missing labels, and the returned bool
+// tells whether the key was.
+func (t *CCHandler) writeKey(cc *v1.CustomConfig, key, value string, remove bool) (bool, error) {
+	if t.dryRun(cc) {
+		diff, err := t.dryRunDiff(cc, key, value, remove)
+		if err == nil && diff != "" {
+			log.Infof("dry run, not changing config map %s/%s:\n%s", TargetNamespace(cc), cc.Spec.ConfigmapName, diff)
+		}
+		return false, err
+	}
+	if cc.Spec.Immutable != nil {
+		return t.writeGeneration(cc, key, value, remove)
+	}
+
+	configmapName := cc.Spec.ConfigmapName
+	ns := TargetNamespace(cc)
+	cms := t.Client.CoreV1().ConfigMaps(ns)
+
+	cm, err := cms.Get(configmapName, meta_v1.GetOptions{})
+	if errors.IsNotFound(err) {
+		if remove {
+			return false, nil
+		}
+		cm = &core_v1.ConfigMap{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      configmapName,
+				Namespace: ns,
+				Annotations: map[string]string{
+					ownedKeysAnnotation: key,
+				},
+			},
+			Data: map[string]string{
+				key: value,
+			},
+		}
+		setLabels(&cm.ObjectMeta, targetLabels(cc))
+		if _, err = cms.Create(cm); err != nil {
+			return false, err
+		}
+		log.Info("config map created")
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	owned := ownedKeys(cm)
+	current, exists := cm.Data[key]
+	if remove {
+		if !exists {
+			return false, nil
+		}
+		ownsAll := ownsEveryKey(cm, owned)
+		delete(cm.Data, key)
+		delete(owned, key)
+		setOwnedKeys(cm, owned)
+		if len(cm.Data) == 0 && len(cm.BinaryData) == 0 {
+			if ownsAll && cc.Spec.DeletionPolicy != v1.DeletionRetainConfigMap {
+				return true, cms.Delete(cm.Name, nil)
+			}
+			log.Infof("keeping empty config map %s/%s", ns, cm.Name)
+		}
+	} else {
+		// a key which already has the value was not written by the
+		// operator, it does not become owned
+		if exists && current == value {
+			if setLabels(&cm.ObjectMeta, targetLabels(cc)) {
+				_, err = cms.Update(cm)
+			}
+			return false, err
+		}
+		setLabels(&cm.ObjectMeta, targetLabels(cc))
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[key] = value
+		owned[key] = true
+		setOwnedKeys(cm, owned)
+	}
+
+	if cm, err = cms.Update(cm); err != nil {
+		return false, err
+	}
+	t.restartConsumers(cc, cm)
+	return true, nil
+}
+
+// restartConsumers rolls the workloads consuming the ConfigMap cc changed,
+// following the restart policy of cc
+func (t *CCHandler) restartConsumers(cc *v1.CustomConfig, cm *core_v1.ConfigMap) {
+	if t.Restarter == nil {
 		return
 	}
+
+	policy := cc.Spec.RestartPolicy
+	if policy == "" {
+		policy = t.RestartPolicy
+	}
+	checksum := contentHash(cm.Data)
+
+	switch policy {
+	case v1.RestartManual:
+		log.Infof("config map %s/%s changed, its consumers are to be restarted manually", cm.Namespace, cm.Name)
+	case v1.RestartDebounced:
+		t.Restarter.RestartDebounced(cm.Namespace, cm.Name, checksum)
+	default:
+		if err := t.Restarter.Restart(cm.Namespace, cm.Name, checksum); err != nil {
+			log.Errorf("restarting consumers of config map %s/%s: %v", cm.Namespace, cm.Name, err)
+		}
+	}
 }
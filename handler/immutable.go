@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	"github.com/onkarbanerjee/crd-operator/workload"
+	log "github.com/sirupsen/logrus"
+	core_v1 "k8s.io/api/core/v1"
+	errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// sourceLabel marks every generation of a ConfigMap with its base name
+	sourceLabel = "mtcil.com/configmap"
+	// generationAnnotation orders the generations of a ConfigMap
+	generationAnnotation = "mtcil.com/generation"
+	// defaultRetain is the number of old generations kept by default
+	defaultRetain = 3
+)
+
+// writeGeneration publishes the ConfigMap targeted by cc, with key set to
+// value or removed, as a new generation named <configmapName>-<hash>, which
+// the API server keeps from being changed but for its labels. The workloads selected by cc are
+// then pointed at the new generation and the generations beyond the
+// retention count are deleted. Removing the last key publishes nothing, the
+// generations are deleted or left as they are as the deletion policy of cc
+// says.
+func (t *CCHandler) writeGeneration(cc *v1.CustomConfig, key, value string, remove bool) (bool, error) {
+	ns := TargetNamespace(cc)
+	base := cc.Spec.ConfigmapName
+	cms := t.Client.CoreV1().ConfigMaps(ns)
+
+	generations, err := t.generations(ns, base)
+	if err != nil {
+		return false, err
+	}
+
+	// the first generation is seeded from the mutable ConfigMap, if any,
+	// so that switching an existing ConfigMap to immutable keeps its data
+	data := map[string]string{}
+	var next int64 = 1
+	if len(generations) > 0 {
+		current := &generations[0]
+		next = generationOf(current) + 1
+		for k, v := range current.Data {
+			data[k] = v
+		}
+	} else {
+		cm, err := cms.Get(base, meta_v1.GetOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return false, err
+		}
+		if err == nil {
+			for k, v := range cm.Data {
+				data[k] = v
+			}
+		}
+	}
+
+	if remove {
+		delete(data, key)
+		if len(data) == 0 {
+			return t.removeGenerations(cc, ns, base, generations)
+		}
+	} else {
+		data[key] = value
+	}
+
+	name := base + "-" + contentHash(data)
+	changed := len(generations) == 0 || generations[0].Name != name
+	if changed {
+		cm := &core_v1.ConfigMap{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+				Labels: map[string]string{
+					sourceLabel: base,
+				},
+				Annotations: map[string]string{
+					generationAnnotation: strconv.FormatInt(next, 10),
+				},
+			},
+			Data: data,
+		}
+		_, err = cms.Create(cm)
+		if errors.IsAlreadyExists(err) {
+			// the content went back to the one of an older generation,
+			// which only needs to be promoted, an update would unset
+			// immutable
+			var patch []byte
+			patch, err = json.Marshal(map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{generationAnnotation: strconv.FormatInt(next, 10)},
+				},
+			})
+			if err == nil {
+				_, err = cms.Patch(name, types.MergePatchType, patch)
+			}
+		}
+		if err != nil {
+			return false, err
+		}
+		log.Infof("published generation %d of config map %s as %s", next, base, name)
+	}
+	// the ConfigMap of k8s.io/api v0.17 has no immutable field, it is set by
+	// a patch, which is repeated until it succeeds. The labels of the
+	// target, which stay mutable, are set along with it.
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata":  map[string]interface{}{"labels": targetLabels(cc)},
+		"immutable": true,
+	})
+	if err != nil {
+		return changed, err
+	}
+	if _, err = cms.Patch(name, types.MergePatchType, patch); err != nil {
+		return changed, fmt.Errorf("making generation %s immutable: %v", name, err)
+	}
+
+	t.rewriteReferences(cc, ns, name, generations)
+	t.collectGenerations(cc, ns, name, generations)
+	return changed, nil
+}
+
+// removeGenerations deletes every generation of the base ConfigMap once
+// its last key is removed, unless the deletion policy of cc retains the
+// ConfigMap, which leaves the current generation and the workloads as they
+// are
+func (t *CCHandler) removeGenerations(cc *v1.CustomConfig, ns, base string, generations []core_v1.ConfigMap) (bool, error) {
+	if len(generations) == 0 {
+		return false, nil
+	}
+	if cc.Spec.DeletionPolicy == v1.DeletionRetainConfigMap {
+		log.Infof("keeping generation %s of config map %s/%s, which has no key left", generations[0].Name, ns, base)
+		return false, nil
+	}
+	for i := range generations {
+		err := t.Client.CoreV1().ConfigMaps(ns).Delete(generations[i].Name, nil)
+		if err != nil && !errors.IsNotFound(err) {
+			return true, err
+		}
+		log.Infof("deleted generation %s of config map %s/%s, which has no key left", generations[i].Name, ns, base)
+	}
+	return true, nil
+}
+
+// generations returns the generations of the base ConfigMap, newest first
+func (t *CCHandler) generations(ns, base string) ([]core_v1.ConfigMap, error) {
+	list, err := t.Client.CoreV1().ConfigMaps(ns).List(meta_v1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{sourceLabel: base}).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	generations := list.Items
+	sort.Slice(generations, func(i, j int) bool {
+		return generationOf(&generations[i]) > generationOf(&generations[j])
+	})
+	return generations, nil
+}
+
+// rewriteReferences points every reference the workloads selected by cc
+// hold to the base ConfigMap or to one of its generations at name
+func (t *CCHandler) rewriteReferences(cc *v1.CustomConfig, ns, name string, generations []core_v1.ConfigMap) {
+	selector := labels.Everything()
+	if cc.Spec.Immutable.Selector != nil {
+		var err error
+		selector, err = meta_v1.LabelSelectorAsSelector(cc.Spec.Immutable.Selector)
+		if err != nil {
+			log.Errorf("invalid workload selector of customconfig %s/%s: %v", cc.Namespace, cc.Name, err)
+			return
+		}
+	}
+
+	known := map[string]bool{cc.Spec.ConfigmapName: true}
+	for i := range generations {
+		known[generations[i].Name] = true
+	}
+
+	workloads, err := workload.List(t.Client, ns, selector)
+	if err != nil {
+		log.Error("error is", err)
+		return
+	}
+	for _, w := range workloads {
+		changed := false
+		workload.VisitConfigMapNames(&w.Template.Spec, func(ref *string) {
+			if known[*ref] && *ref != name {
+				*ref = name
+				changed = true
+			}
+		})
+		if !changed {
+			continue
+		}
+		if err = w.Update(t.Client); err != nil {
+			log.Errorf("pointing %s %s/%s at %s: %v", w.Kind, ns, w.Name, name, err)
+			continue
+		}
+		log.Infof("pointed %s %s/%s at config map %s", w.Kind, ns, w.Name, name)
+	}
+}
+
+// collectGenerations deletes the generations older than the retention
+// count of cc, never deleting current
+func (t *CCHandler) collectGenerations(cc *v1.CustomConfig, ns, current string, generations []core_v1.ConfigMap) {
+	retain := defaultRetain
+	if cc.Spec.Immutable.Retain != nil {
+		retain = int(*cc.Spec.Immutable.Retain)
+	}
+
+	kept := 0
+	for i := range generations {
+		if generations[i].Name == current {
+			continue
+		}
+		if kept < retain {
+			kept++
+			continue
+		}
+		err := t.Client.CoreV1().ConfigMaps(ns).Delete(generations[i].Name, nil)
+		if err != nil && !errors.IsNotFound(err) {
+			log.Error("error is", err)
+			continue
+		}
+		log.Infof("deleted old generation %s", generations[i].Name)
+	}
+}
+
+// generationOf returns the generation number of a generation ConfigMap
+func generationOf(cm *core_v1.ConfigMap) int64 {
+	n, _ := strconv.ParseInt(cm.Annotations[generationAnnotation], 10, 64)
+	return n
+}
+
+// contentHash returns a short hash of the ConfigMap data, independent of the
+// order of its keys
+func contentHash(data map[string]string) string {
+	// json.Marshal sorts the keys of maps
+	b, _ := json.Marshal(data)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])[:10]
+}
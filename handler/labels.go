@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"strings"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	log "github.com/sirupsen/logrus"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// targetLabels returns the labels cc sets on the ConfigMap or Secret it
+// writes, leaving out the invalid ones and the ones the operator sets itself
+func targetLabels(cc *v1.CustomConfig) map[string]string {
+	labels, _ := v1.TargetLabels(cc)
+	valid := map[string]string{}
+	for k, v := range labels {
+		problems := append(validation.IsQualifiedName(k), validation.IsValidLabelValue(v)...)
+		if k == sourceLabel {
+			problems = append(problems, "set by the operator")
+		}
+		if len(problems) > 0 {
+			log.Warnf("not setting label %s of customconfig %s/%s: %s", k, cc.Namespace, cc.Name, strings.Join(problems, ", "))
+			continue
+		}
+		valid[k] = v
+	}
+	return valid
+}
+
+// setLabels sets labels on meta, and tells whether any changed
+func setLabels(meta *meta_v1.ObjectMeta, labels map[string]string) bool {
+	changed := false
+	for k, v := range labels {
+		if current, ok := meta.Labels[k]; ok && current == v {
+			continue
+		}
+		if meta.Labels == nil {
+			meta.Labels = map[string]string{}
+		}
+		meta.Labels[k] = v
+		changed = true
+	}
+	return changed
+}
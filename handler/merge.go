@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	log "github.com/sirupsen/logrus"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+)
+
+// conflict records a leaf path set to different values by two fragments
+type conflict struct {
+	path   string
+	loser  string
+	winner string
+}
+
+// mergeContributors returns every CustomConfig merging into the given key of
+// the given ConfigMap in merge order, i.e. by priority and then by
+// namespace/name
+func (t *CCHandler) mergeContributors(ns, configmapName, key string) ([]*v1.CustomConfig, error) {
+	ccs, err := t.Lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var contributors []*v1.CustomConfig
+	for _, cc := range ccs {
+		if TargetNamespace(cc) == ns && cc.Spec.ConfigmapName == configmapName && cc.Spec.Key == key && cc.Spec.File == nil && cc.Spec.Strategy == v1.StrategyMerge && active(cc) {
+			contributors = append(contributors, cc)
+		}
+	}
+	sort.Slice(contributors, func(i, j int) bool {
+		a, b := contributors[i], contributors[j]
+		if a.Spec.Priority != b.Spec.Priority {
+			return a.Spec.Priority < b.Spec.Priority
+		}
+		return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+	})
+	return contributors, nil
+}
+
+// mergedContent re-merges the key cc contributes a fragment to from every
+// CustomConfig currently merging into it, so that it is called alike for
+// creates, updates and deletes, and returns the key with its content. The
+// key is to be removed once nothing merges into it.
+func (t *CCHandler) mergedContent(cc *v1.CustomConfig) (string, string, bool, error) {
+	contributors, err := t.mergeContributors(TargetNamespace(cc), cc.Spec.ConfigmapName, cc.Spec.Key)
+	if err != nil {
+		return "", "", false, fmt.Errorf("listing contributors of %s: %v", cc.Spec.Key, err)
+	}
+	if len(contributors) == 0 {
+		return cc.Spec.Key, "", true, nil
+	}
+
+	merged, conflicts := t.mergeFragments(cc, contributors)
+	t.reportConflicts(contributors, cc.Spec.Key, conflicts)
+
+	content, err := renderMerged(cc.Spec.Key, merged)
+	if err != nil {
+		return "", "", false, fmt.Errorf("rendering %s: %v", cc.Spec.Key, err)
+	}
+	return cc.Spec.Key, content, false, nil
+}
+
+// mergeFragments deep-merges the values of the contributors to the key self
+// merges into, in order. Maps
+// are merged key by key while anything else is a leaf that the later
+// contributor overrides, which is reported as a conflict when the values
+// differ. A fragment which is not an object is left out and reported, as an
+// invalid value is.
+func (t *CCHandler) mergeFragments(self *v1.CustomConfig, contributors []*v1.CustomConfig) (map[string]interface{}, []conflict) {
+	var (
+		merged    = map[string]interface{}{}
+		owners    = map[string]string{}
+		conflicts []conflict
+	)
+	for _, cc := range contributors {
+		owner := cc.Namespace + "/" + cc.Name
+		value, err := t.contributedValue(self, cc)
+		if err != nil {
+			log.Warnf("leaving customconfig %s out of the merge: %v", owner, err)
+			continue
+		}
+		fragment, err := parseFragment(value)
+		if err != nil {
+			log.Warnf("customconfig %s has an invalid fragment, leaving it out of the merge: %v", owner, err)
+			t.reportOnce(cc, core_v1.EventTypeWarning, "InvalidFragment", fmt.Sprintf("fragment left out of the merge of %s: %v", cc.Spec.Key, err))
+			continue
+		}
+		t.reportOnce(cc, core_v1.EventTypeWarning, "InvalidFragment", "")
+		conflicts = mergeInto(merged, fragment, "", owner, owners, conflicts)
+	}
+	return merged, conflicts
+}
+
+// reportConflicts records on each contributor to key the leaves it
+// overrides and the ones overridden, once until they change
+func (t *CCHandler) reportConflicts(contributors []*v1.CustomConfig, key string, conflicts []conflict) {
+	messages := map[string][]string{}
+	for _, c := range conflicts {
+		log.Warnf("customconfig %s overrides %s of %s set by %s", c.winner, c.path, key, c.loser)
+		messages[c.winner] = append(messages[c.winner], fmt.Sprintf("overrides %s set by %s", c.path, c.loser))
+		messages[c.loser] = append(messages[c.loser], fmt.Sprintf("%s overridden by %s", c.path, c.winner))
+	}
+	for _, cc := range contributors {
+		message := ""
+		if m := messages[cc.Namespace+"/"+cc.Name]; len(m) > 0 {
+			message = fmt.Sprintf("conflicts in %s: %s", key, strings.Join(m, ", "))
+		}
+		t.reportOnce(cc, core_v1.EventTypeWarning, "MergeConflict", message)
+	}
+}
+
+// parseFragment parses a JSON or YAML fragment, which must be an object
+func parseFragment(value string) (map[string]interface{}, error) {
+	js, err := yaml.YAMLToJSON([]byte(value))
+	if err != nil {
+		return nil, err
+	}
+	var fragment map[string]interface{}
+	if err = json.Unmarshal(js, &fragment); err != nil {
+		return nil, fmt.Errorf("not an object: %v", err)
+	}
+	if fragment == nil {
+		fragment = map[string]interface{}{}
+	}
+	return fragment, nil
+}
+
+// mergeInto merges src into dst, recording in owners which contributor set
+// each leaf path below prefix
+func mergeInto(dst, src map[string]interface{}, prefix, owner string, owners map[string]string, conflicts []conflict) []conflict {
+	keys := make([]string, 0, len(src))
+	for k := range src {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		p := k
+		if prefix != "" {
+			p = prefix + "." + k
+		}
+		srcMap, srcIsMap := src[k].(map[string]interface{})
+		dstMap, dstIsMap := dst[k].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			conflicts = mergeInto(dstMap, srcMap, p, owner, owners, conflicts)
+			continue
+		}
+
+		if existing, ok := dst[k]; ok && !reflect.DeepEqual(existing, src[k]) {
+			for _, loser := range leafOwners(owners, p) {
+				if loser != owner {
+					conflicts = append(conflicts, conflict{path: p, loser: loser, winner: owner})
+				}
+			}
+		}
+		for leaf := range owners {
+			if leaf == p || strings.HasPrefix(leaf, p+".") {
+				delete(owners, leaf)
+			}
+		}
+
+		dst[k] = src[k]
+		if srcIsMap {
+			recordOwner(srcMap, p, owner, owners)
+		} else {
+			owners[p] = owner
+		}
+	}
+	return conflicts
+}
+
+// recordOwner records owner for every leaf path of m below prefix
+func recordOwner(m map[string]interface{}, prefix, owner string, owners map[string]string) {
+	for k, v := range m {
+		if sub, ok := v.(map[string]interface{}); ok {
+			recordOwner(sub, prefix+"."+k, owner, owners)
+			continue
+		}
+		owners[prefix+"."+k] = owner
+	}
+}
+
+// leafOwners returns the distinct owners of p and of every leaf below it
+func leafOwners(owners map[string]string, p string) []string {
+	seen := map[string]bool{}
+	var result []string
+	for leaf, owner := range owners {
+		if (leaf == p || strings.HasPrefix(leaf, p+".")) && !seen[owner] {
+			seen[owner] = true
+			result = append(result, owner)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// renderMerged renders the merged document as JSON when the key has a .json
+// extension and as YAML otherwise
+func renderMerged(key string, merged map[string]interface{}) (string, error) {
+	if strings.ToLower(path.Ext(key)) == ".json" {
+		out, err := json.MarshalIndent(merged, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(out) + "\n", nil
+	}
+	out, err := yaml.Marshal(merged)
+	return string(out), err
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	"github.com/onkarbanerjee/crd-operator/pkg/client/clientset/versioned"
+	log "github.com/sirupsen/logrus"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+)
+
+// OverrideHandler is the Handler of CustomConfigOverrides. The overridden
+// value itself is written by the CCHandler, which is asked to sync the
+// overridden CustomConfig whenever an override starts or ends.
+type OverrideHandler struct {
+	CC *CCHandler
+	// CCClient is used to write the status of the CustomConfigOverrides
+	CCClient versioned.Interface
+	// Recorder records the start and end of the overrides
+	Recorder record.EventRecorder
+	// Enqueue syncs the CustomConfig with the given namespace/name key
+	Enqueue func(key string)
+	// Requeue syncs a CustomConfigOverride again after a delay, at its
+	// expiration
+	Requeue func(key string, delay time.Duration)
+}
+
+// Init handles any handler initialization
+func (t *OverrideHandler) Init() error {
+	log.Info("OverrideHandler.Init")
+	return nil
+}
+
+// ObjectCreated is called when an object is created
+func (t *OverrideHandler) ObjectCreated(obj interface{}) {
+	log.Info("OverrideHandler.ObjectCreated")
+	if o, ok := obj.(*v1.CustomConfigOverride); ok {
+		t.sync(o)
+	}
+}
+
+// ObjectDeleted is called when an object is deleted
+func (t *OverrideHandler) ObjectDeleted(obj interface{}) {
+	log.Info("OverrideHandler.ObjectDeleted")
+	o, ok := obj.(*v1.CustomConfigOverride)
+	if !ok {
+		return
+	}
+
+	if o.Status.Phase == v1.OverrideActive {
+		log.Infof("customconfigoverride %s/%s deleted, restoring customconfig %s", o.Namespace, o.Name, o.Spec.CustomConfigName)
+		if cc := t.customConfig(o); cc != nil {
+			recordEvent(t.Recorder, cc, core_v1.EventTypeNormal, "OverrideRemoved",
+				"override %s was deleted, restoring the value", o.Name)
+		}
+	}
+	t.enqueueCustomConfig(o)
+}
+
+// ObjectUpdated is called when an object is updated
+func (t *OverrideHandler) ObjectUpdated(obj interface{}) {
+	log.Info("OverrideHandler.ObjectUpdated")
+	if o, ok := obj.(*v1.CustomConfigOverride); ok {
+		t.sync(o)
+	}
+}
+
+// sync has the overridden CustomConfig synced, records the start and the
+// end of the override and wakes up at its expiration
+func (t *OverrideHandler) sync(o *v1.CustomConfigOverride) {
+	expiration := overrideExpiration(o)
+	status := o.Status.DeepCopy()
+	status.ExpirationTime = &meta_v1.Time{Time: expiration}
+	status.Phase = v1.OverrideExpired
+	if time.Now().Before(expiration) {
+		status.Phase = v1.OverrideActive
+		if t.Requeue != nil {
+			t.Requeue(o.Namespace+"/"+o.Name, time.Until(expiration)+time.Second)
+		}
+	}
+
+	if status.Phase != o.Status.Phase {
+		cc := t.customConfig(o)
+		switch status.Phase {
+		case v1.OverrideActive:
+			log.Infof("customconfigoverride %s/%s overrides customconfig %s until %s", o.Namespace, o.Name, o.Spec.CustomConfigName, expiration)
+			recordEvent(t.Recorder, o, core_v1.EventTypeNormal, "OverrideApplied",
+				"overriding customconfig %s until %s", o.Spec.CustomConfigName, expiration.Format(time.RFC3339))
+			if cc != nil {
+				recordEvent(t.Recorder, cc, core_v1.EventTypeNormal, "OverrideApplied",
+					"value overridden by %s until %s", o.Name, expiration.Format(time.RFC3339))
+			}
+		case v1.OverrideExpired:
+			log.Infof("customconfigoverride %s/%s expired, restoring customconfig %s", o.Namespace, o.Name, o.Spec.CustomConfigName)
+			recordEvent(t.Recorder, o, core_v1.EventTypeNormal, "OverrideExpired",
+				"expired, restoring the value of customconfig %s", o.Spec.CustomConfigName)
+			if cc != nil {
+				recordEvent(t.Recorder, cc, core_v1.EventTypeNormal, "OverrideExpired",
+					"override %s expired, restoring the value", o.Name)
+			}
+		}
+	}
+	t.enqueueCustomConfig(o)
+
+	if t.CCClient == nil || equality.Semantic.DeepEqual(o.Status, *status) {
+		return
+	}
+	updated := o.DeepCopy()
+	updated.Status = *status
+	_, err := t.CCClient.MtcilV1().CustomConfigOverrides(o.Namespace).UpdateStatus(context.TODO(), updated, meta_v1.UpdateOptions{})
+	if err != nil {
+		log.Errorf("updating status of customconfigoverride %s/%s: %v", o.Namespace, o.Name, err)
+	}
+}
+
+// enqueueCustomConfig has the CustomConfig overridden by o synced
+func (t *OverrideHandler) enqueueCustomConfig(o *v1.CustomConfigOverride) {
+	if t.Enqueue != nil {
+		t.Enqueue(o.Namespace + "/" + o.Spec.CustomConfigName)
+	}
+}
+
+// customConfig returns the CustomConfig overridden by o, nil when it does
+// not exist, which the callers check before recording events about it as
+// the nil is typed
+func (t *OverrideHandler) customConfig(o *v1.CustomConfigOverride) *v1.CustomConfig {
+	if t.CC == nil || t.CC.Lister == nil {
+		return nil
+	}
+	cc, err := t.CC.Lister.CustomConfigs(o.Namespace).Get(o.Spec.CustomConfigName)
+	if err != nil {
+		return nil
+	}
+	return cc
+}
+
+// activeOverride returns the override of cc which has not expired yet, the
+// most recent one when there are several
+func (t *CCHandler) activeOverride(cc *v1.CustomConfig) *v1.CustomConfigOverride {
+	if t.Overrides == nil {
+		return nil
+	}
+	overrides, err := t.Overrides.CustomConfigOverrides(cc.Namespace).List(labels.Everything())
+	if err != nil {
+		log.Error("error is", err)
+		return nil
+	}
+
+	now := time.Now()
+	var active []*v1.CustomConfigOverride
+	for _, o := range overrides {
+		if o.Spec.CustomConfigName == cc.Name && o.DeletionTimestamp == nil && now.Before(overrideExpiration(o)) {
+			active = append(active, o)
+		}
+	}
+	if len(active) == 0 {
+		return nil
+	}
+	sort.Slice(active, func(i, j int) bool {
+		a, b := active[i].CreationTimestamp, active[j].CreationTimestamp
+		if !a.Equal(&b) {
+			return b.Before(&a)
+		}
+		return active[i].Name < active[j].Name
+	})
+	return active[0]
+}
+
+// overrideExpiration returns when the TTL of o is over
+func overrideExpiration(o *v1.CustomConfigOverride) time.Time {
+	return o.CreationTimestamp.Add(o.Spec.TTL.Duration)
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"sort"
+	"strings"
+
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ownedKeysAnnotation lists the keys of a ConfigMap or Secret written by the
+// operator, as opposed to the ones written by other tools
+const ownedKeysAnnotation = "mtcil.com/owned-keys"
+
+// ownedKeys returns the keys of obj written by the operator
+func ownedKeys(obj meta_v1.Object) map[string]bool {
+	owned := map[string]bool{}
+	for _, k := range strings.Split(obj.GetAnnotations()[ownedKeysAnnotation], ",") {
+		if k != "" {
+			owned[k] = true
+		}
+	}
+	return owned
+}
+
+// setOwnedKeys records the keys of obj written by the operator
+func setOwnedKeys(obj meta_v1.Object, owned map[string]bool) {
+	keys := make([]string, 0, len(owned))
+	for k := range owned {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	annotations := obj.GetAnnotations()
+	if len(keys) == 0 {
+		delete(annotations, ownedKeysAnnotation)
+		return
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ownedKeysAnnotation] = strings.Join(keys, ",")
+	obj.SetAnnotations(annotations)
+}
+
+// ownsEveryKey tells whether every key of cm was written by the operator
+func ownsEveryKey(cm *core_v1.ConfigMap, owned map[string]bool) bool {
+	if len(cm.BinaryData) > 0 {
+		return false
+	}
+	for k := range cm.Data {
+		if !owned[k] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	listers "github.com/onkarbanerjee/crd-operator/pkg/client/listers/customconfig/v1"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// EffectivePolicy returns base together with the specs of every
+// CustomConfigPolicy, which only add to it
+func EffectivePolicy(base v1.CustomConfigPolicySpec, lister listers.CustomConfigPolicyLister) (v1.CustomConfigPolicySpec, error) {
+	if lister == nil {
+		return base, nil
+	}
+	policies, err := lister.List(labels.Everything())
+	if err != nil {
+		return base, err
+	}
+	policy := *base.DeepCopy()
+	for _, p := range policies {
+		policy.ProtectedNamespaces = append(policy.ProtectedNamespaces, p.Spec.ProtectedNamespaces...)
+		policy.ProtectedConfigMaps = append(policy.ProtectedConfigMaps, p.Spec.ProtectedConfigMaps...)
+		policy.ReservedKeyPrefixes = append(policy.ReservedKeyPrefixes, p.Spec.ReservedKeyPrefixes...)
+	}
+	return policy, nil
+}
+
+// CheckPolicy returns the field of cc which writes to a namespace,
+// ConfigMap or key policy protects and why, nil when there is none
+func CheckPolicy(policy v1.CustomConfigPolicySpec, cc *v1.CustomConfig) (*field.Path, error) {
+	spec := field.NewPath("spec")
+	ns := TargetNamespace(cc)
+	for _, pattern := range policy.ProtectedNamespaces {
+		if matches(pattern, ns) {
+			return spec.Child("targetNamespace"), fmt.Errorf("namespace %s is protected", ns)
+		}
+	}
+	if !encrypted(cc) {
+		for _, pattern := range policy.ProtectedConfigMaps {
+			name := cc.Spec.ConfigmapName
+			if strings.Contains(pattern, "/") {
+				name = ns + "/" + name
+			}
+			if matches(pattern, name) {
+				return spec.Child("configmapName"), fmt.Errorf("config map %s/%s is protected", ns, cc.Spec.ConfigmapName)
+			}
+		}
+	}
+	key := ConfigMapKey(cc)
+	for _, prefix := range policy.ReservedKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			keyPath := spec.Child("key")
+			if cc.Spec.File != nil {
+				keyPath = spec.Child("file", "name")
+			}
+			return keyPath, fmt.Errorf("key %s has the reserved prefix %s", key, prefix)
+		}
+	}
+	return nil, nil
+}
+
+// matches tells whether name matches the shell pattern, an invalid pattern
+// only matching itself
+func matches(pattern, name string) bool {
+	ok, err := path.Match(pattern, name)
+	if err != nil {
+		return pattern == name
+	}
+	return ok
+}
+
+// policyViolation returns why cc writes to what the policy of the operator
+// protects, nil when it does not
+func (t *CCHandler) policyViolation(cc *v1.CustomConfig) error {
+	_, err := t.policyField(cc)
+	return err
+}
+
+// policyField returns the field of cc which writes to what the policy of the
+// operator protects and why, nil when there is none
+func (t *CCHandler) policyField(cc *v1.CustomConfig) (*field.Path, error) {
+	policy, err := EffectivePolicy(t.Policy, t.Policies)
+	if err != nil {
+		log.Error("error is", err)
+	}
+	return CheckPolicy(policy, cc)
+}
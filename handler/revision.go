@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	log "github.com/sirupsen/logrus"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// revisionLabel marks the ControllerRevisions of a CustomConfig with
+	// its name
+	revisionLabel = "mtcil.com/customconfig"
+	// defaultRevisionHistoryLimit is the number of revisions kept by default
+	defaultRevisionHistoryLimit = 10
+)
+
+// revisionData is the content of the ControllerRevision of a value
+type revisionData struct {
+	Value string `json:"value"`
+}
+
+// recordRevision stores value as the latest revision of cc, reusing the
+// revision of an older identical value, deletes the revisions beyond the
+// history limit of cc and records the revisions kept in status
+func (t *CCHandler) recordRevision(cc *v1.CustomConfig, value string, status *v1.CustomConfigStatus) error {
+	revisions, err := t.revisions(cc)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(revisionData{Value: value})
+	if err != nil {
+		return err
+	}
+	name := cc.Name + "-" + contentHash(map[string]string{"value": value})
+	var next int64 = 1
+	if len(revisions) > 0 {
+		next = revisions[0].Revision + 1
+	}
+
+	crs := t.Client.AppsV1().ControllerRevisions(cc.Namespace)
+	var current *apps_v1.ControllerRevision
+	for i := range revisions {
+		if revisions[i].Name == name {
+			current = &revisions[i]
+			break
+		}
+	}
+	switch {
+	case current == nil:
+		current = &apps_v1.ControllerRevision{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      name,
+				Namespace: cc.Namespace,
+				Labels: map[string]string{
+					revisionLabel: cc.Name,
+				},
+				OwnerReferences: []meta_v1.OwnerReference{
+					*meta_v1.NewControllerRef(cc, v1.SchemeGroupVersion.WithKind("CustomConfig")),
+				},
+			},
+			Data:     runtime.RawExtension{Raw: data},
+			Revision: next,
+		}
+		if current, err = crs.Create(current); err != nil {
+			return err
+		}
+		revisions = append([]apps_v1.ControllerRevision{*current}, revisions...)
+		log.Infof("recorded revision %d of customconfig %s/%s", next, cc.Namespace, cc.Name)
+	case current.Revision != revisions[0].Revision:
+		// going back to an older value makes its revision the latest
+		current.Revision = next
+		if _, err = crs.Update(current); err != nil {
+			return err
+		}
+		sort.Slice(revisions, func(i, j int) bool {
+			return revisions[i].Revision > revisions[j].Revision
+		})
+		log.Infof("revision of customconfig %s/%s %s is now revision %d", cc.Namespace, cc.Name, name, next)
+	}
+
+	limit := defaultRevisionHistoryLimit
+	if cc.Spec.RevisionHistoryLimit != nil {
+		limit = int(*cc.Spec.RevisionHistoryLimit)
+	}
+	if limit < 1 {
+		limit = 1
+	}
+	if len(revisions) > limit {
+		for _, r := range revisions[limit:] {
+			err = crs.Delete(r.Name, nil)
+			if err != nil && !errors.IsNotFound(err) {
+				log.Errorf("deleting revision %s of customconfig %s/%s: %v", r.Name, cc.Namespace, cc.Name, err)
+			}
+		}
+		revisions = revisions[:limit]
+	}
+
+	status.CurrentRevision = revisions[0].Revision
+	status.Revisions = nil
+	for i := len(revisions) - 1; i >= 0; i-- {
+		status.Revisions = append(status.Revisions, v1.Revision{
+			Revision:          revisions[i].Revision,
+			Name:              revisions[i].Name,
+			CreationTimestamp: revisions[i].CreationTimestamp,
+		})
+	}
+	return nil
+}
+
+// revisions returns the ControllerRevisions of cc, latest first
+func (t *CCHandler) revisions(cc *v1.CustomConfig) ([]apps_v1.ControllerRevision, error) {
+	list, err := t.Client.AppsV1().ControllerRevisions(cc.Namespace).List(meta_v1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{revisionLabel: cc.Name}).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var revisions []apps_v1.ControllerRevision
+	for _, r := range list.Items {
+		// revisions of a deleted CustomConfig of the same name are left to
+		// the garbage collector
+		if ref := meta_v1.GetControllerOf(&r); ref != nil && ref.UID == cc.UID {
+			revisions = append(revisions, r)
+		}
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision > revisions[j].Revision
+	})
+	return revisions, nil
+}
+
+// rollBackTo sets the value of cc back to the one of its spec.rollbackTo
+// revision and clears spec.rollbackTo, and tells whether it did. The value
+// is then applied by the update of cc this causes, like any other change. A
+// value taken from an external source is pinned, the source may not give it
+// anymore. A revision cc does not have is reported in status and
+// spec.rollbackTo is left for the user to correct.
+func (t *CCHandler) rollBackTo(cc *v1.CustomConfig, status *v1.CustomConfigStatus) (bool, error) {
+	if t.CCClient == nil {
+		return false, nil
+	}
+
+	revision := *cc.Spec.RollbackTo
+	revisions, err := t.revisions(cc)
+	if err != nil {
+		return false, err
+	}
+	var data *revisionData
+	for _, r := range revisions {
+		if r.Revision != revision {
+			continue
+		}
+		data = &revisionData{}
+		if err = json.Unmarshal(r.Data.Raw, data); err != nil {
+			return false, fmt.Errorf("reading revision %d: %v", revision, err)
+		}
+		break
+	}
+	if data == nil {
+		message := fmt.Sprintf("there is no revision %d to roll back to, clear or correct spec.rollbackTo", revision)
+		if c := getCondition(cc.Status, v1.ConditionRolledBack); c == nil || c.Reason != "RevisionNotFound" || c.Message != message {
+			log.Warnf("customconfig %s/%s has no revision %d to roll back to", cc.Namespace, cc.Name, revision)
+			recordEvent(t.Recorder, cc, core_v1.EventTypeWarning, "RevisionNotFound", "%s", message)
+		}
+		setCondition(status, v1.ConditionRolledBack, core_v1.ConditionFalse, "RevisionNotFound", message)
+		return false, nil
+	}
+
+	log.Infof("rolling customconfig %s/%s back to revision %d", cc.Namespace, cc.Name, revision)
+	updated := cc.DeepCopy()
+	updated.Spec.RollbackTo = nil
+	updated.Spec.Value = data.Value
+	updated.Spec.ValueFrom = nil
+	if _, err = t.CCClient.MtcilV1().CustomConfigs(cc.Namespace).Update(context.TODO(), updated, meta_v1.UpdateOptions{}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
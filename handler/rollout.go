@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	"github.com/onkarbanerjee/crd-operator/workload"
+	log "github.com/sirupsen/logrus"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultRolloutWindow is how long consumers have to become healthy when
+// the CustomConfig does not say
+const defaultRolloutWindow = 5 * time.Minute
+
+// rolloutGate is the rollout of a ConfigMap watched in the background
+type rolloutGate struct {
+	cancel context.CancelFunc
+}
+
+// gateRollout watches, in the background, the rollout of the Deployments
+// consuming the ConfigMap cc just changed and rolls the change back to
+// previous when they do not become healthy in time. A newer change of the
+// ConfigMap stops watching the rollout of the previous one, as does the
+// operator stopping.
+func (t *CCHandler) gateRollout(cc *v1.CustomConfig, previous string) {
+	window := defaultRolloutWindow
+	if cc.Spec.Rollout.Window != nil {
+		window = cc.Spec.Rollout.Window.Duration
+	}
+
+	// under the Debounced policy the consumers only start rolling once
+	// the debounce period is over
+	var delay time.Duration
+	policy := cc.Spec.RestartPolicy
+	if policy == "" {
+		policy = t.RestartPolicy
+	}
+	if policy == v1.RestartDebounced && t.Restarter != nil {
+		delay = t.Restarter.Debounce
+	}
+
+	ns, name, generation := cc.Namespace, cc.Name, cc.Generation
+	target := TargetNamespace(cc)
+	// the consumers of an immutable ConfigMap refer to its latest
+	// generation, just published, rather than to its base name
+	configmapNames := []string{cc.Spec.ConfigmapName}
+	if cc.Spec.Immutable != nil {
+		generations, err := t.generations(target, cc.Spec.ConfigmapName)
+		if err != nil {
+			log.Error("error is", err)
+		} else if len(generations) > 0 {
+			configmapNames = append(configmapNames, generations[0].Name)
+		}
+	}
+
+	key := target + "/" + cc.Spec.ConfigmapName
+	ctx, cancel := context.WithCancel(context.Background())
+	gate := &rolloutGate{cancel: cancel}
+	t.mu.Lock()
+	if previousGate, ok := t.gates[key]; ok {
+		previousGate.cancel()
+	}
+	if t.gates == nil {
+		t.gates = map[string]*rolloutGate{}
+	}
+	t.gates[key] = gate
+	t.mu.Unlock()
+
+	go func() {
+		defer func() {
+			cancel()
+			t.mu.Lock()
+			if t.gates[key] == gate {
+				delete(t.gates, key)
+			}
+			t.mu.Unlock()
+		}()
+		go func() {
+			select {
+			case <-t.Stop:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		}
+		err := workload.WaitRollout(t.Client, target, configmapNames, window, ctx.Done())
+		switch {
+		case err == workload.ErrStopped || ctx.Err() != nil:
+			log.Infof("stopped watching the rollout of customconfig %s/%s generation %d", ns, name, generation)
+		case err == nil:
+			log.Infof("rollout of customconfig %s/%s generation %d succeeded", ns, name, generation)
+		default:
+			log.Warnf("rollout of customconfig %s/%s generation %d failed, rolling back: %v", ns, name, generation, err)
+			t.rollBack(ns, name, generation, previous, err.Error())
+		}
+	}()
+}
+
+// rollBack marks the generation of the CustomConfig as rolled back to
+// previous, unless it was superseded in the meantime. Writing previous to
+// the ConfigMap is then left to the update of the CustomConfig this causes.
+func (t *CCHandler) rollBack(ns, name string, generation int64, previous, message string) {
+	if t.CCClient == nil {
+		return
+	}
+
+	cc, err := t.CCClient.MtcilV1().CustomConfigs(ns).Get(context.TODO(), name, meta_v1.GetOptions{})
+	if err != nil {
+		log.Errorf("rolling back customconfig %s/%s: %v", ns, name, err)
+		return
+	}
+	if cc.Generation != generation {
+		log.Infof("customconfig %s/%s changed since generation %d, not rolling it back", ns, name, generation)
+		return
+	}
+
+	status := cc.Status.DeepCopy()
+	status.RolledBackGeneration = generation
+	status.AppliedValue = previous
+	if err = t.recordRevision(cc, previous, status); err != nil {
+		log.Errorf("recording revision of customconfig %s/%s: %v", ns, name, err)
+	}
+	setCondition(status, v1.ConditionRolledBack, core_v1.ConditionTrue, "RolloutFailed", message)
+	t.updateStatus(cc, status)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	"github.com/robfig/cron/v3"
+)
+
+// maxOverlappingWindows bounds the walk through the windows of a schedule
+// which overlap, e.g. hourly windows lasting two hours
+const maxOverlappingWindows = 1000
+
+// activity tells whether cc is active at now, i.e. within its activity
+// window and one of the windows of its schedule, and when that changes next,
+// which is nil when it never does
+func activity(cc *v1.CustomConfig, now time.Time) (bool, *time.Time, error) {
+	from, until := cc.Spec.ActiveFrom, cc.Spec.ActiveUntil
+	if until != nil && !now.Before(until.Time) {
+		return false, nil, nil
+	}
+
+	active := true
+	var next *time.Time
+	// before ActiveFrom, what matters is the state of the schedule then
+	at := now
+	if from != nil && now.Before(from.Time) {
+		active = false
+		next = &from.Time
+		at = from.Time
+	}
+	if cc.Spec.Schedule != nil {
+		inWindow, transition, err := scheduled(cc.Spec.Schedule, at)
+		if err != nil {
+			return false, nil, err
+		}
+		if active {
+			active, next = inWindow, &transition
+		} else if !inWindow {
+			next = &transition
+		}
+	}
+
+	if until != nil {
+		if active && (next == nil || until.Time.Before(*next)) {
+			next = &until.Time
+		}
+		if !active && next != nil && !next.Before(until.Time) {
+			next = nil
+		}
+	}
+	return active, next, nil
+}
+
+// scheduled tells whether now is within a window of the schedule and when
+// the current window ends or the next one starts
+func scheduled(schedule *v1.ScheduleSpec, now time.Time) (bool, time.Time, error) {
+	s, err := cron.ParseStandard(schedule.Cron)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid schedule %q: %v", schedule.Cron, err)
+	}
+	duration := schedule.Duration.Duration
+	if duration <= 0 {
+		return false, time.Time{}, fmt.Errorf("invalid schedule duration %s", duration)
+	}
+
+	// the first window started after now-duration is the one now may be in
+	start := s.Next(now.Add(-duration))
+	if start.After(now) {
+		return false, start, nil
+	}
+	end := start.Add(duration)
+	for i := 0; i < maxOverlappingWindows; i++ {
+		start = s.Next(start)
+		if start.After(end) {
+			break
+		}
+		end = start.Add(duration)
+	}
+	return true, end, nil
+}
+
+// active tells whether cc currently sets its key
+func active(cc *v1.CustomConfig) bool {
+	a, _, err := activity(cc, time.Now())
+	return a && err == nil
+}
@@ -0,0 +1,308 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	"github.com/onkarbanerjee/crd-operator/pkg/client/clientset/versioned"
+	listers "github.com/onkarbanerjee/crd-operator/pkg/client/listers/customconfig/v1"
+	log "github.com/sirupsen/logrus"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// SchemaHandler is the Handler of ConfigSchemas. It writes the defaults of
+// the keys no CustomConfig provides and reports the required ones missing.
+type SchemaHandler struct {
+	CC *CCHandler
+	// CCClient is used to write the status of the ConfigSchemas
+	CCClient versioned.Interface
+	// Synced tells whether the CustomConfigs are all known, no default is
+	// written before as one of them may provide the key
+	Synced func() bool
+	// Requeue syncs a ConfigSchema again after a delay
+	Requeue func(key string, delay time.Duration)
+}
+
+// ConfigMapKey returns the ConfigMap key cc writes to, which is the file
+// of a file contributor
+func ConfigMapKey(cc *v1.CustomConfig) string {
+	if cc.Spec.File != nil {
+		return cc.Spec.File.Name
+	}
+	return cc.Spec.Key
+}
+
+// SchemasOf returns the ConfigSchemas of the ConfigMap cc writes to
+func SchemasOf(lister listers.ConfigSchemaLister, cc *v1.CustomConfig) ([]*v1.ConfigSchema, error) {
+	if lister == nil {
+		return nil, nil
+	}
+	schemas, err := lister.ConfigSchemas(TargetNamespace(cc)).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var matching []*v1.ConfigSchema
+	for _, s := range schemas {
+		if s.Spec.ConfigmapName == cc.Spec.ConfigmapName {
+			matching = append(matching, s)
+		}
+	}
+	return matching, nil
+}
+
+// CheckSchemas checks that the ConfigSchemas of the ConfigMap cc writes to
+// declare its key, and that value meets the type and constraints of that
+// key. The values of file entries and merged fragments are only parts of
+// the key, which is all that is checked for them, as it is when value is
+// nil.
+func CheckSchemas(schemas []*v1.ConfigSchema, cc *v1.CustomConfig, value *string) error {
+	key := ConfigMapKey(cc)
+	for _, s := range schemas {
+		k := schemaKey(s, key)
+		if k == nil {
+			return fmt.Errorf("key %s is not declared by configschema %s/%s", key, s.Namespace, s.Name)
+		}
+		if value == nil || cc.Spec.File != nil || cc.Spec.Strategy == v1.StrategyMerge {
+			continue
+		}
+		if _, err := NormalizeValue(k.Type, k.Constraints, *value); err != nil {
+			return fmt.Errorf("key %s does not match configschema %s/%s: %v", key, s.Namespace, s.Name, err)
+		}
+	}
+	return nil
+}
+
+// schemaKey returns the key of s with the given name, nil when s does not
+// declare it
+func schemaKey(s *v1.ConfigSchema, name string) *v1.SchemaKey {
+	for i := range s.Spec.Keys {
+		if s.Spec.Keys[i].Name == name {
+			return &s.Spec.Keys[i]
+		}
+	}
+	return nil
+}
+
+// validatedValue returns the normalized desired value of cc, or the reason
+// it is invalid and why. It is only asked for a value to be written, a key
+// is removed without checking the signature or the value of cc.
+func (t *CCHandler) validatedValue(cc *v1.CustomConfig) (string, string, error) {
+	// an unverified CustomConfig does not contribute to the keys it shares
+	if _, reason, err := t.verifySignature(cc); err != nil {
+		return "", reason, err
+	}
+	// nor does one awaiting the approval of its first value or frozen
+	// before it
+	if cc.Status.AppliedGeneration == 0 && t.pendingApproval(cc) {
+		return "", "PendingApproval", fmt.Errorf("generation %d awaits approval", cc.Generation)
+	}
+	if cc.Status.AppliedGeneration == 0 && t.frozen(cc) {
+		return "", "Frozen", fmt.Errorf("generation %d is held by a configfreeze", cc.Generation)
+	}
+	value, err := t.normalizedValue(cc)
+	if err != nil {
+		return "", "InvalidValue", err
+	}
+	schemas, err := SchemasOf(t.Schemas, cc)
+	if err != nil {
+		return "", "", err
+	}
+	if err = CheckSchemas(schemas, cc, &value); err != nil {
+		return "", "SchemaViolation", err
+	}
+	return value, "", nil
+}
+
+// hasSchema tells whether the ConfigMap cc writes to has a ConfigSchema
+func (t *CCHandler) hasSchema(cc *v1.CustomConfig) bool {
+	schemas, err := SchemasOf(t.Schemas, cc)
+	return err == nil && len(schemas) > 0
+}
+
+// Init handles any handler initialization
+func (t *SchemaHandler) Init() error {
+	log.Info("SchemaHandler.Init")
+	return nil
+}
+
+// ObjectCreated is called when an object is created
+func (t *SchemaHandler) ObjectCreated(obj interface{}) {
+	log.Info("SchemaHandler.ObjectCreated")
+	if s, ok := obj.(*v1.ConfigSchema); ok {
+		t.sync(s)
+	}
+}
+
+// ObjectDeleted is called when an object is deleted, the defaults it wrote
+// are left in the ConfigMap
+func (t *SchemaHandler) ObjectDeleted(obj interface{}) {
+	log.Info("SchemaHandler.ObjectDeleted")
+	if s, ok := obj.(*v1.ConfigSchema); ok {
+		log.Infof("configschema %s/%s deleted, keeping the defaults it set in config map %s", s.Namespace, s.Name, s.Spec.ConfigmapName)
+	}
+}
+
+// ObjectUpdated is called when an object is updated
+func (t *SchemaHandler) ObjectUpdated(obj interface{}) {
+	log.Info("SchemaHandler.ObjectUpdated")
+	if s, ok := obj.(*v1.ConfigSchema); ok {
+		t.sync(s)
+	}
+}
+
+// sync sets the keys of s no CustomConfig provides to their default, when
+// they are not in the ConfigMap yet, and records the required keys left
+// missing in the status of s
+func (t *SchemaHandler) sync(s *v1.ConfigSchema) {
+	if t.CC.paused() {
+		log.Infof("the operator is paused, not syncing configschema %s/%s", s.Namespace, s.Name)
+		return
+	}
+	if t.Synced != nil && !t.Synced() {
+		if t.Requeue != nil {
+			t.Requeue(s.Namespace+"/"+s.Name, time.Second)
+		}
+		return
+	}
+
+	provided, err := t.providedKeys(s)
+	if err != nil {
+		log.Errorf("listing customconfigs of configschema %s/%s: %v", s.Namespace, s.Name, err)
+		return
+	}
+	data := map[string]string{}
+	cm, err := t.CC.Client.CoreV1().ConfigMaps(s.Namespace).Get(s.Spec.ConfigmapName, meta_v1.GetOptions{})
+	switch {
+	case err == nil:
+		data = cm.Data
+	case !errors.IsNotFound(err):
+		log.Error("error is", err)
+		return
+	}
+
+	status := s.Status.DeepCopy()
+	status.MissingKeys = nil
+	status.DefaultedKeys = nil
+	var invalid []string
+	for _, k := range s.Spec.Keys {
+		if provided[k.Name] {
+			continue
+		}
+		if k.Default == nil {
+			if k.Required {
+				status.MissingKeys = append(status.MissingKeys, k.Name)
+			}
+			continue
+		}
+
+		value, err := NormalizeValue(k.Type, k.Constraints, *k.Default)
+		if err != nil {
+			log.Warnf("configschema %s/%s has an invalid default for key %s: %v", s.Namespace, s.Name, k.Name, err)
+			invalid = append(invalid, fmt.Sprintf("%s: %v", k.Name, err))
+			if k.Required {
+				status.MissingKeys = append(status.MissingKeys, k.Name)
+			}
+			continue
+		}
+		current, exists := data[k.Name]
+		if !exists {
+			if err = t.CC.policyViolation(defaulting(s, k.Name, value)); err != nil {
+				log.Warnf("not setting key %s of configschema %s/%s to its default: %v", k.Name, s.Namespace, s.Name, err)
+				invalid = append(invalid, fmt.Sprintf("%s: %v", k.Name, err))
+				continue
+			}
+			if _, err = t.CC.writeKey(defaulting(s, k.Name, value), k.Name, value, false); err != nil {
+				log.Errorf("setting key %s of configschema %s/%s to its default: %v", k.Name, s.Namespace, s.Name, err)
+				continue
+			}
+			log.Infof("set key %s of config map %s/%s to its default", k.Name, s.Namespace, s.Spec.ConfigmapName)
+			current = value
+		}
+		if current == value {
+			status.DefaultedKeys = append(status.DefaultedKeys, k.Name)
+		}
+	}
+
+	switch {
+	case len(invalid) > 0:
+		setSchemaCondition(status, core_v1.ConditionFalse, "InvalidDefault", strings.Join(invalid, "; "))
+	case len(status.MissingKeys) > 0:
+		setSchemaCondition(status, core_v1.ConditionFalse, "MissingKeys", "required keys not provided: "+strings.Join(status.MissingKeys, ", "))
+	default:
+		setSchemaCondition(status, core_v1.ConditionTrue, "Satisfied", "")
+	}
+	// a dry run sets no default, which the status is not to tell otherwise
+	if t.CC.DryRun {
+		return
+	}
+	t.updateStatus(s, status)
+}
+
+// providedKeys returns the keys of the ConfigMap of s which an active
+// CustomConfig with a valid value writes to
+func (t *SchemaHandler) providedKeys(s *v1.ConfigSchema) (map[string]bool, error) {
+	ccs, err := t.CC.Lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	provided := map[string]bool{}
+	now := time.Now()
+	for _, cc := range ccs {
+		if encrypted(cc) || TargetNamespace(cc) != s.Namespace || cc.Spec.ConfigmapName != s.Spec.ConfigmapName {
+			continue
+		}
+		if isActive, _, err := activity(cc, now); err != nil || !isActive {
+			continue
+		}
+		if _, _, err := t.CC.validatedValue(cc); err != nil {
+			continue
+		}
+		provided[ConfigMapKey(cc)] = true
+	}
+	return provided, nil
+}
+
+// setSchemaCondition sets the Satisfied condition of a ConfigSchema
+func setSchemaCondition(status *v1.ConfigSchemaStatus, conditionStatus core_v1.ConditionStatus, reason, message string) {
+	ccStatus := &v1.CustomConfigStatus{Conditions: status.Conditions}
+	setCondition(ccStatus, v1.ConditionSatisfied, conditionStatus, reason, message)
+	status.Conditions = ccStatus.Conditions
+}
+
+// updateStatus writes status as the status of s, unless it did not change
+func (t *SchemaHandler) updateStatus(s *v1.ConfigSchema, status *v1.ConfigSchemaStatus) {
+	if t.CCClient == nil || equality.Semantic.DeepEqual(s.Status, *status) {
+		return
+	}
+
+	updated := s.DeepCopy()
+	updated.Status = *status
+	_, err := t.CCClient.MtcilV1().ConfigSchemas(s.Namespace).UpdateStatus(context.TODO(), updated, meta_v1.UpdateOptions{})
+	if err != nil {
+		log.Errorf("updating status of configschema %s/%s: %v", s.Namespace, s.Name, err)
+	}
+}
+
+// defaulting returns the CustomConfig setting key of the ConfigMap of s to
+// its default value
+func defaulting(s *v1.ConfigSchema, key, value string) *v1.CustomConfig {
+	return &v1.CustomConfig{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      s.Name,
+			Namespace: s.Namespace,
+		},
+		Spec: v1.CustomConfigSpec{
+			Key:             key,
+			Value:           value,
+			ConfigmapName:   s.Spec.ConfigmapName,
+			TargetNamespace: s.Namespace,
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	"github.com/onkarbanerjee/crd-operator/signature"
+)
+
+// verifySignature checks the signature of cc when its namespace requires
+// signed CustomConfigs, which it tells, and returns why cc is rejected
+func (t *CCHandler) verifySignature(cc *v1.CustomConfig) (bool, string, error) {
+	if t.TrustedKeys == nil {
+		return false, "", nil
+	}
+	keys, required := t.TrustedKeys(cc.Namespace)
+	if !required {
+		return false, "", nil
+	}
+	var defaults v1.CustomConfigDefaultsSpec
+	if t.Defaults != nil {
+		defaults = t.Defaults(cc.Namespace)
+	}
+	err := signature.Verify(keys, cc, defaults)
+	switch {
+	case err == nil:
+		return true, "", nil
+	case cc.Annotations[signature.Annotation] == "":
+		return true, "Unsigned", err
+	}
+	return true, "InvalidSignature", err
+}
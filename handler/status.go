@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	log "github.com/sirupsen/logrus"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// setCondition sets the condition of the given type, keeping its last
+// transition time unless its status changes
+func setCondition(status *v1.CustomConfigStatus, conditionType v1.ConditionType, conditionStatus core_v1.ConditionStatus, reason, message string) {
+	for i := range status.Conditions {
+		c := &status.Conditions[i]
+		if c.Type != conditionType {
+			continue
+		}
+		if c.Status != conditionStatus {
+			c.Status = conditionStatus
+			c.LastTransitionTime = meta_v1.Now()
+		}
+		c.Reason = reason
+		c.Message = message
+		return
+	}
+	status.Conditions = append(status.Conditions, v1.CustomConfigCondition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		LastTransitionTime: meta_v1.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// getCondition returns the condition of the given type, nil when there is
+// none
+func getCondition(status v1.CustomConfigStatus, conditionType v1.ConditionType) *v1.CustomConfigCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == conditionType {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// clearCondition sets the condition of the given type to false, when it is
+// there at all
+func clearCondition(status *v1.CustomConfigStatus, conditionType v1.ConditionType, reason string) {
+	for _, c := range status.Conditions {
+		if c.Type == conditionType {
+			setCondition(status, conditionType, core_v1.ConditionFalse, reason, "")
+			return
+		}
+	}
+}
+
+// updateStatus writes status as the status of cc, unless it did not change
+func (t *CCHandler) updateStatus(cc *v1.CustomConfig, status *v1.CustomConfigStatus) {
+	if t.CCClient == nil || equality.Semantic.DeepEqual(cc.Status, *status) {
+		return
+	}
+
+	updated := cc.DeepCopy()
+	updated.Status = *status
+	_, err := t.CCClient.MtcilV1().CustomConfigs(cc.Namespace).UpdateStatus(context.TODO(), updated, meta_v1.UpdateOptions{})
+	if err != nil {
+		log.Errorf("updating status of customconfig %s/%s: %v", cc.Namespace, cc.Name, err)
+	}
+}
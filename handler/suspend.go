@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"fmt"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	log "github.com/sirupsen/logrus"
+	core_v1 "k8s.io/api/core/v1"
+	errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PausedAnnotation, set to "true" on the control ConfigMap of the operator,
+// pauses every write of the operator to the ConfigMaps, e.g. during a
+// maintenance window
+const PausedAnnotation = "mtcil.com/paused"
+
+// IsPaused tells whether the control ConfigMap pauses the operator
+func IsPaused(cm *core_v1.ConfigMap) bool {
+	return cm != nil && cm.Annotations[PausedAnnotation] == "true"
+}
+
+// paused tells whether the operator is paused
+func (t *CCHandler) paused() bool {
+	return t.Paused != nil && t.Paused()
+}
+
+// deferDelete remembers remove, which removes what a deleted resource
+// wrote, to run it once the operator resumes as nothing is written while it
+// is paused. The deletions deferred are lost when the operator restarts.
+func (t *CCHandler) deferDelete(key string, remove func()) {
+	log.Infof("the operator is paused, deferring the removal of %s until it resumes", key)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.deferred == nil {
+		t.deferred = map[string]func(){}
+	}
+	t.deferred[key] = remove
+}
+
+// ResumeDeletes runs the removals deferred while the operator was paused
+func (t *CCHandler) ResumeDeletes() {
+	t.mu.Lock()
+	deferred := t.deferred
+	t.deferred = nil
+	t.mu.Unlock()
+
+	for key, remove := range deferred {
+		log.Infof("the operator resumed, removing %s", key)
+		remove()
+	}
+}
+
+// suspension returns why cc is not to write its ConfigMap, empty when it is
+func (t *CCHandler) suspension(cc *v1.CustomConfig) (string, string) {
+	if t.paused() {
+		return "OperatorPaused", fmt.Sprintf("the operator is paused by the %s annotation", PausedAnnotation)
+	}
+	if cc.Spec.Suspend {
+		return "Suspended", "spec.suspend is set"
+	}
+	return "", ""
+}
+
+// contributedValue returns the value cc contributes to a file or merged key
+// it shares with self, the CustomConfig being synced or deleted. A suspended
+// or dry run contributor is not written by the sync of another one, it keeps
+// the value it last applied, or stays out when it never applied any. A
+// contributor whose author may not write the ConfigMap stays out as well.
+func (t *CCHandler) contributedValue(self, cc *v1.CustomConfig) (string, error) {
+	own := cc.Namespace == self.Namespace && cc.Name == self.Name
+	if !own && (t.AuthorizeWrites || foreignTarget(cc)) {
+		if _, _, err := t.authorizeWrite(cc); err != nil {
+			return "", err
+		}
+	}
+	if own || !cc.Spec.Suspend && !cc.Spec.DryRun {
+		value, _, err := t.validatedValue(cc)
+		return value, err
+	}
+	if cc.Status.AppliedGeneration == 0 {
+		if cc.Spec.Suspend {
+			return "", fmt.Errorf("suspended before applying any value")
+		}
+		return "", fmt.Errorf("running dry before applying any value")
+	}
+	return cc.Status.AppliedValue, nil
+}
+
+// reportDrift records in status whether the ConfigMap of the suspended cc
+// differs from what cc would write
+func (t *CCHandler) reportDrift(cc *v1.CustomConfig, status *v1.CustomConfigStatus, isActive bool) {
+	key, value, remove, err := t.desiredContent(cc, !isActive)
+	if err != nil {
+		log.Errorf("computing the desired value of customconfig %s/%s: %v", cc.Namespace, cc.Name, err)
+		return
+	}
+	current, exists, err := t.currentValue(cc, key)
+	if err != nil {
+		log.Errorf("reading the config map of customconfig %s/%s: %v", cc.Namespace, cc.Name, err)
+		return
+	}
+
+	where := fmt.Sprintf("key %s of config map %s/%s", key, TargetNamespace(cc), cc.Spec.ConfigmapName)
+	switch {
+	case remove && exists:
+		setCondition(status, v1.ConditionDrifted, core_v1.ConditionTrue, "Drifted", where+" is set but would be removed")
+	case !remove && !exists:
+		setCondition(status, v1.ConditionDrifted, core_v1.ConditionTrue, "Drifted", where+" is missing")
+	case !remove && current != value:
+		setCondition(status, v1.ConditionDrifted, core_v1.ConditionTrue, "Drifted", where+" differs from the desired value")
+	default:
+		setCondition(status, v1.ConditionDrifted, core_v1.ConditionFalse, "InSync", "")
+	}
+}
+
+// currentValue returns the value of key in the ConfigMap cc writes to, or
+// in its latest generation when it is immutable
+func (t *CCHandler) currentValue(cc *v1.CustomConfig, key string) (string, bool, error) {
+	data, err := t.currentData(cc)
+	if err != nil {
+		return "", false, err
+	}
+	value, ok := data[key]
+	return value, ok, nil
+}
+
+// currentData returns the data of the ConfigMap cc writes to, or of its
+// latest generation when it is immutable, nil when there is none
+func (t *CCHandler) currentData(cc *v1.CustomConfig) (map[string]string, error) {
+	ns := TargetNamespace(cc)
+	if cc.Spec.Immutable != nil {
+		generations, err := t.generations(ns, cc.Spec.ConfigmapName)
+		if err != nil {
+			return nil, err
+		}
+		if len(generations) > 0 {
+			return generations[0].Data, nil
+		}
+	}
+
+	cm, err := t.Client.CoreV1().ConfigMaps(ns).Get(cc.Spec.ConfigmapName, meta_v1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cm.Data, nil
+}
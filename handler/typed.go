@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// NormalizeValue checks that value has the given type and meets the
+// constraints, and returns its canonical form: a plain integer, true or
+// false, a Go duration or compact JSON with sorted keys. Untyped values,
+// strings and YAML are returned as they are.
+func NormalizeValue(valueType v1.ValueType, constraints *v1.ValueConstraints, value string) (string, error) {
+	if valueType == "" {
+		return value, nil
+	}
+
+	normalized, err := normalize(valueType, value)
+	if err != nil {
+		return "", err
+	}
+	if constraints == nil {
+		return normalized, nil
+	}
+
+	if err = checkBounds(valueType, constraints, normalized); err != nil {
+		return "", err
+	}
+	if len(constraints.Enum) > 0 {
+		allowed := false
+		for _, e := range constraints.Enum {
+			// the allowed values are compared in their canonical form too
+			if n, err := normalize(valueType, e); err == nil && n == normalized {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return "", fmt.Errorf("%q is not one of %s", normalized, strings.Join(constraints.Enum, ", "))
+		}
+	}
+	if constraints.Pattern != "" {
+		re, err := regexp.Compile(constraints.Pattern)
+		if err != nil {
+			return "", fmt.Errorf("invalid pattern %q: %v", constraints.Pattern, err)
+		}
+		if !re.MatchString(normalized) {
+			return "", fmt.Errorf("%q does not match %s", normalized, constraints.Pattern)
+		}
+	}
+	return normalized, nil
+}
+
+// normalize returns the canonical form of value of the given type
+func normalize(valueType v1.ValueType, value string) (string, error) {
+	switch valueType {
+	case v1.ValueString:
+		return value, nil
+	case v1.ValueInt:
+		i, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("%q is not an int", value)
+		}
+		return strconv.FormatInt(i, 10), nil
+	case v1.ValueBool:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return "", fmt.Errorf("%q is not a bool", value)
+		}
+		return strconv.FormatBool(b), nil
+	case v1.ValueDuration:
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return "", fmt.Errorf("%q is not a duration", value)
+		}
+		return d.String(), nil
+	case v1.ValueJSON:
+		var v interface{}
+		decoder := json.NewDecoder(strings.NewReader(value))
+		// numbers are kept as they are written
+		decoder.UseNumber()
+		if err := decoder.Decode(&v); err != nil {
+			return "", fmt.Errorf("invalid json: %v", err)
+		}
+		if decoder.More() {
+			return "", fmt.Errorf("invalid json: more than one value")
+		}
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(v); err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(buf.String(), "\n"), nil
+	case v1.ValueYAML:
+		// YAML is only checked, rendering it again could change the meaning
+		// of unquoted scalars such as y or 0755
+		if _, err := yaml.YAMLToJSON([]byte(value)); err != nil {
+			return "", fmt.Errorf("invalid yaml: %v", err)
+		}
+		return value, nil
+	}
+	return "", fmt.Errorf("unknown type %q", valueType)
+}
+
+// checkBounds checks the minimum and maximum of constraints, which bound
+// ints and durations and the length of strings
+func checkBounds(valueType v1.ValueType, constraints *v1.ValueConstraints, value string) error {
+	if constraints.Minimum == "" && constraints.Maximum == "" {
+		return nil
+	}
+
+	parse := func(s string) (int64, error) {
+		return strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	}
+	subject := value
+	switch valueType {
+	case v1.ValueInt:
+	case v1.ValueDuration:
+		parse = func(s string) (int64, error) {
+			d, err := time.ParseDuration(strings.TrimSpace(s))
+			return int64(d), err
+		}
+	case v1.ValueString:
+		subject = strconv.Itoa(len(value))
+	default:
+		return fmt.Errorf("minimum and maximum do not apply to %s values", valueType)
+	}
+
+	v, err := parse(subject)
+	if err != nil {
+		return err
+	}
+	if constraints.Minimum != "" {
+		min, err := parse(constraints.Minimum)
+		if err != nil {
+			return fmt.Errorf("invalid minimum %q", constraints.Minimum)
+		}
+		if v < min {
+			return fmt.Errorf("%s is less than the minimum %s", describe(valueType, value), constraints.Minimum)
+		}
+	}
+	if constraints.Maximum != "" {
+		max, err := parse(constraints.Maximum)
+		if err != nil {
+			return fmt.Errorf("invalid maximum %q", constraints.Maximum)
+		}
+		if v > max {
+			return fmt.Errorf("%s is more than the maximum %s", describe(valueType, value), constraints.Maximum)
+		}
+	}
+	return nil
+}
+
+// describe names value in the errors about its bounds
+func describe(valueType v1.ValueType, value string) string {
+	if valueType == v1.ValueString {
+		return fmt.Sprintf("length %d", len(value))
+	}
+	return strconv.Quote(value)
+}
+
+// normalizedValue returns the normalized desired value of cc
+func (t *CCHandler) normalizedValue(cc *v1.CustomConfig) (string, error) {
+	return NormalizeValue(cc.Spec.Type, cc.Spec.Constraints, t.desiredValue(cc))
+}
@@ -1,20 +1,44 @@
 package main
 
 import (
+	"crypto/ed25519"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
 	"os"
 	"os/signal"
+	"reflect"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/onkarbanerjee/crd-operator/controller"
+	"github.com/onkarbanerjee/crd-operator/encryption"
 	"github.com/onkarbanerjee/crd-operator/handler"
+	customconfigv1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
 	"github.com/onkarbanerjee/crd-operator/pkg/client/clientset/versioned"
+	mtcilscheme "github.com/onkarbanerjee/crd-operator/pkg/client/clientset/versioned/scheme"
 	v1 "github.com/onkarbanerjee/crd-operator/pkg/client/informers/externalversions/customconfig/v1"
+	listers "github.com/onkarbanerjee/crd-operator/pkg/client/listers/customconfig/v1"
+	"github.com/onkarbanerjee/crd-operator/signature"
+	"github.com/onkarbanerjee/crd-operator/webhook"
+	"github.com/onkarbanerjee/crd-operator/workload"
 	log "github.com/sirupsen/logrus"
+	core_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcore_v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 )
 
@@ -26,7 +50,7 @@ func getKubernetesClient() (kubernetes.Interface, versioned.Interface) {
 	// create the config from the path
 	config, err := clientcmd.BuildConfigFromFlags("", kubeConfigPath)
 	if err != nil {
-		log.Error("getClusterConfig: %v", err)
+		log.Errorf("getClusterConfig: %v", err)
 		config, err = rest.InClusterConfig()
 		if err != nil {
 			log.Fatalf("getClusterConfig: %v", err)
@@ -50,9 +74,49 @@ func getKubernetesClient() (kubernetes.Interface, versioned.Interface) {
 
 // main code path
 func main() {
+	restartPolicy := flag.String("restart-policy", string(customconfigv1.RestartImmediate), "how workloads consuming a changed config map are restarted: Immediate, Debounced or Manual")
+	restartDebounce := flag.Duration("restart-debounce", 30*time.Second, "how long a config map must stop changing before its consumers are restarted under the Debounced policy")
+	controlConfigMap := flag.String("control-configmap", "crd-operator-control", "config map of the namespace of the operator whose "+handler.PausedAnnotation+" annotation pauses the operator")
+	dryRun := flag.Bool("dry-run", false, "only report the changes the operator would make to the config maps, as events and in the status of the custom configs")
+	protectedNamespaces := flag.String("protected-namespaces", "kube-system,kube-public,kube-node-lease", "comma separated namespaces no custom config may write to, as patterns, e.g. kube-*")
+	protectedConfigMaps := flag.String("protected-configmaps", "kube-root-ca.crt", "comma separated patterns of the names, or namespace/name, of the config maps no custom config may write to")
+	reservedKeyPrefixes := flag.String("reserved-key-prefixes", "", "comma separated prefixes of the keys no custom config may write")
+	webhookAddr := flag.String("webhook-addr", "", "address the admission webhooks are served on over HTTPS, e.g. :8443, they are not served when empty")
+	webhookCertDir := flag.String("webhook-cert-dir", "/tmp/crd-operator-webhook", "directory of the tls.crt, tls.key and ca.crt of the webhooks")
+	webhookSelfSigned := flag.Bool("webhook-self-signed", false, "generate a self-signed certificate into --webhook-cert-dir when it has none and inject its CA into the webhook configurations and the customconfigs definition, for local testing")
+	webhookService := flag.String("webhook-service", "crd-operator", "service of the webhooks in the namespace of the operator, which a self-signed certificate is issued for")
+	webhookConfig := flag.String("webhook-config", "crd-operator", "validating and mutating webhook configurations the CA of a self-signed certificate is injected into")
+	defaultConfigmapName := flag.String("default-configmap-name", "", "config map name of the custom configs which leave it empty, unless the customconfigdefaults of their namespace set one")
+	defaultTargetNamespace := flag.String("default-target-namespace", "", "target namespace of the custom configs which leave it empty, unless the customconfigdefaults of their namespace set one")
+	defaultDeletionPolicy := flag.String("default-deletion-policy", "", "deletion policy of the custom configs which leave it empty, unless the customconfigdefaults of their namespace set one")
+	decryptionKeyFile := flag.String("decryption-key-file", "", "file holding the base64 private key the encrypted values are decrypted with")
+	decryptionKeySecret := flag.String("decryption-key-secret", "", "secret of the namespace of the operator whose "+decryptionKeySecretKey+" key holds the private key the encrypted values are decrypted with, when --decryption-key-file is not set")
+	signingKeysConfigMap := flag.String("signing-keys-configmap", "crd-operator-signing-keys", "config map of the namespace of the operator mapping each namespace whose custom configs must be signed to the base64 ed25519 public keys trusted to sign them")
+	authorizeWrites := flag.Bool("authorize-writes", false, "only write the config maps and secrets the user who last changed a custom config, as recorded by the webhooks, could write directly, checked with a subject access review")
+	serviceAccount := flag.String("service-account", "default", "service account of the operator in its namespace, whose changes to the custom configs are not recorded as theirs by the webhooks")
+	externalTimeout := flag.Duration("external-timeout", 10*time.Second, "timeout of the requests fetching the values of the http and vault sources")
+	flag.Parse()
+
+	if flag.NArg() > 0 {
+		runCommand(flag.Args())
+		return
+	}
+
 	// get the Kubernetes client for connectivity
 	client, customconfigClient := getKubernetesClient()
 
+	if *authorizeWrites && *webhookAddr == "" {
+		log.Warn("--authorize-writes checks the authors the webhooks record, which are not served without --webhook-addr")
+	}
+	if *webhookAddr == "" {
+		log.Warnf("approvals are not recorded without --webhook-addr, the changes in the namespaces labelled %s are held", handler.RequireApprovalLabel)
+	}
+
+	controlNamespace := os.Getenv("NAMESPACE")
+	if controlNamespace == "" {
+		controlNamespace = "default"
+	}
+
 	// retrieve our custom resource informer which was generated from
 	// the code generator and pass it the custom resource client, specifying
 	// we should be looking through all namespaces for listing and watching
@@ -75,50 +139,7 @@ func main() {
 	//  - adding new resources
 	//  - updating existing resources
 	//  - deleting resources
-	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			// convert the resource object into a key (in this case
-			// we are just doing it in the format of 'namespace/name')
-			key, err := cache.MetaNamespaceKeyFunc(obj)
-			log.Infof("Add customconfig: %s", key)
-			i := &controller.Item{
-				Key:        key,
-				Event_type: controller.CREATED,
-			}
-			if err == nil {
-				// add the key to the queue for the handler to get
-				queue.Add(i)
-			}
-		},
-		UpdateFunc: func(oldObj, newObj interface{}) {
-			key, err := cache.MetaNamespaceKeyFunc(newObj)
-			log.Infof("Update customconfig: %s", key)
-			i := &controller.Item{
-				Key:        key,
-				Event_type: controller.UPDATED,
-			}
-			if err == nil {
-				queue.Add(i)
-			}
-		},
-		DeleteFunc: func(obj interface{}) {
-			// DeletionHandlingMetaNamsespaceKeyFunc is a helper function that allows
-			// us to check the DeletedFinalStateUnknown existence in the event that
-			// a resource was deleted but it is still contained in the index
-			//
-			// this then in turn calls MetaNamespaceKeyFunc
-			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
-			log.Infof("Delete customconfig: %s", key)
-			deletedItems.Add(key, obj)
-			i := &controller.Item{
-				Key:        key,
-				Event_type: controller.DELETED,
-			}
-			if err == nil {
-				queue.Add(i)
-			}
-		},
-	})
+	informer.AddEventHandler(controller.NewEventHandler(queue, deletedItems))
 
 	// construct the Controller object which has all of the necessary components to
 	// handle logging, connections, informing (listing and watching), the queue,
@@ -133,17 +154,366 @@ func main() {
 	// 	},
 	// }
 
+	// record events about the custom resources as well as the core ones
+	utilruntime.Must(mtcilscheme.AddToScheme(scheme.Scheme))
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcore_v1.EventSinkImpl{Interface: client.CoreV1().Events("")})
+	recorder := broadcaster.NewRecorder(scheme.Scheme, core_v1.EventSource{Component: "crd-operator"})
+
+	// CustomConfigOverrides are looked up whenever a CustomConfig is synced
+	overrideInformer := v1.NewCustomConfigOverrideInformer(customconfigClient, meta_v1.NamespaceAll, 0, cache.Indexers{})
+	overrideQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	overrideDeletedItems := &controller.DeletedItems{
+		M: map[string]interface{}{},
+	}
+	overrideInformer.AddEventHandler(controller.NewEventHandler(overrideQueue, overrideDeletedItems))
+
+	// the ConfigSchemas are looked up whenever a CustomConfig is synced
+	schemaInformer := v1.NewConfigSchemaInformer(customconfigClient, meta_v1.NamespaceAll, 0, cache.Indexers{})
+	schemaQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	schemaDeletedItems := &controller.DeletedItems{
+		M: map[string]interface{}{},
+	}
+	schemaInformer.AddEventHandler(controller.NewEventHandler(schemaQueue, schemaDeletedItems))
+	schemaLister := listers.NewConfigSchemaLister(schemaInformer.GetIndexer())
+
+	decrypter := loadDecryptionKey(client, controlNamespace, *decryptionKeyFile, *decryptionKeySecret)
+
+	// CustomConfigPolicies add to the policy of the flags
+	policyInformer := v1.NewCustomConfigPolicyInformer(customconfigClient, 0, cache.Indexers{})
+	policy := customconfigv1.CustomConfigPolicySpec{
+		ProtectedNamespaces: splitList(*protectedNamespaces),
+		ProtectedConfigMaps: splitList(*protectedConfigMaps),
+		ReservedKeyPrefixes: splitList(*reservedKeyPrefixes),
+	}
+
 	ccHandler := &handler.CCHandler{
-		Client: client,
+		Client:   client,
+		CCClient: customconfigClient,
+		Lister:   listers.NewCustomConfigLister(informer.GetIndexer()),
+		Restarter: &workload.Restarter{
+			Client:   client,
+			Debounce: *restartDebounce,
+		},
+		RestartPolicy:   customconfigv1.RestartPolicy(*restartPolicy),
+		Overrides:       listers.NewCustomConfigOverrideLister(overrideInformer.GetIndexer()),
+		Schemas:         schemaLister,
+		Policy:          policy,
+		Policies:        listers.NewCustomConfigPolicyLister(policyInformer.GetIndexer()),
+		Recorder:        recorder,
+		DryRun:          *dryRun,
+		HTTPClient:      &http.Client{Timeout: *externalTimeout},
+		Decrypter:       decrypter,
+		AuthorizeWrites: *authorizeWrites,
 	}
 
 	ccController := controller.New("custom-config-controller", client, informer, queue, ccHandler, deletedItems)
+	ccHandler.Requeue = ccController.EnqueueAfter
+	ccHandler.RequeueDeleted = ccController.EnqueueDeletedAfter
 	// use a channel to synchronize the finalization for a graceful shutdown
 	stopCh := make(chan struct{})
 	defer close(stopCh)
+	ccHandler.Stop = stopCh
+
+	// the policies must be known before anything is written
+	go policyInformer.Run(stopCh)
+	if !cache.WaitForNamedCacheSync("customconfigpolicies", stopCh, policyInformer.HasSynced) {
+		log.Fatal("error syncing customconfigpolicies cache")
+	}
 
-	// run the controller loop to process items
+	overrideHandler := &handler.OverrideHandler{
+		CC:       ccHandler,
+		CCClient: customconfigClient,
+		Recorder: recorder,
+		Enqueue:  ccController.Enqueue,
+	}
+	overrideController := controller.New("custom-config-override-controller", client, overrideInformer, overrideQueue, overrideHandler, overrideDeletedItems)
+	overrideHandler.Requeue = overrideController.EnqueueAfter
+
+	// the overrides must be known before the CustomConfigs are synced, not
+	// to write their base value meanwhile
+	go overrideController.Run(stopCh)
+	if !cache.WaitForNamedCacheSync("customconfigoverrides", stopCh, overrideInformer.HasSynced) {
+		log.Fatal("error syncing customconfigoverrides cache")
+	}
+
+	// ConfigSchemas default the keys no CustomConfig provides, so they are
+	// reconciled again whenever a CustomConfig comes, goes or changes
+	schemaHandler := &handler.SchemaHandler{
+		CC:       ccHandler,
+		CCClient: customconfigClient,
+		Synced:   informer.HasSynced,
+	}
+	schemaController := controller.New("config-schema-controller", client, schemaInformer, schemaQueue, schemaHandler, schemaDeletedItems)
+	schemaHandler.Requeue = schemaController.EnqueueAfter
+	enqueueSchemas := func(obj interface{}) {
+		schemas, err := schemaLister.List(labels.Everything())
+		if err != nil {
+			log.Error("error is", err)
+			return
+		}
+		for _, s := range schemas {
+			schemaController.Enqueue(s.Namespace + "/" + s.Name)
+		}
+	}
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    enqueueSchemas,
+		UpdateFunc: func(oldObj, newObj interface{}) { enqueueSchemas(newObj) },
+		DeleteFunc: enqueueSchemas,
+	})
+	go schemaController.Run(stopCh)
+	if !cache.WaitForNamedCacheSync("configschemas", stopCh, schemaInformer.HasSynced) {
+		log.Fatal("error syncing configschemas cache")
+	}
+
+	// ClusterCustomConfigs are projected into the matching namespaces, so
+	// they are reconciled again whenever a namespace comes, goes or changes
+	// its labels
+	cccInformer := v1.NewClusterCustomConfigInformer(customconfigClient, 0, cache.Indexers{})
+	cccQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	cccDeletedItems := &controller.DeletedItems{
+		M: map[string]interface{}{},
+	}
+	cccInformer.AddEventHandler(controller.NewEventHandler(cccQueue, cccDeletedItems))
+	cccLister := listers.NewClusterCustomConfigLister(cccInformer.GetIndexer())
+
+	nsInformer := coreinformers.NewNamespaceInformer(client, 0, cache.Indexers{})
+	namespaceLister := corelisters.NewNamespaceLister(nsInformer.GetIndexer())
+	cccHandler := &handler.CCCHandler{
+		CC:         ccHandler,
+		CCClient:   customconfigClient,
+		Namespaces: namespaceLister,
+	}
+	ccHandler.Namespaces = namespaceLister
+	ccHandler.ApprovalsRecorded = *webhookAddr != ""
+	ccHandler.RequiresApproval = func(ns string) bool {
+		namespace, err := namespaceLister.Get(ns)
+		return err == nil && handler.RequiresApproval(namespace)
+	}
+	cccController := controller.New("cluster-custom-config-controller", client, cccInformer, cccQueue, cccHandler, cccDeletedItems)
+	cccHandler.Requeue = cccController.EnqueueAfter
+	cccHandler.RequeueDeleted = cccController.EnqueueDeletedAfter
+
+	enqueueAll := func(obj interface{}) {
+		cccs, err := cccLister.List(labels.Everything())
+		if err != nil {
+			log.Error("error is", err)
+			return
+		}
+		for _, ccc := range cccs {
+			cccController.Enqueue(ccc.Name)
+		}
+	}
+	nsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: enqueueAll,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldNs, newNs := oldObj.(*core_v1.Namespace), newObj.(*core_v1.Namespace)
+			if !reflect.DeepEqual(oldNs.Labels, newNs.Labels) || (oldNs.DeletionTimestamp == nil) != (newNs.DeletionTimestamp == nil) {
+				enqueueAll(newObj)
+			}
+			// the CustomConfigs of a namespace, or writing to it, are held or
+			// released when it starts or stops requiring approval or being
+			// selected by a ConfigFreeze
+			if !reflect.DeepEqual(oldNs.Labels, newNs.Labels) {
+				ccs, err := ccHandler.Lister.List(labels.Everything())
+				if err != nil {
+					log.Error("error is", err)
+					return
+				}
+				for _, cc := range ccs {
+					if cc.Namespace == newNs.Name || handler.TargetNamespace(cc) == newNs.Name {
+						ccController.Enqueue(cc.Namespace + "/" + cc.Name)
+					}
+				}
+			}
+		},
+		DeleteFunc: enqueueAll,
+	})
+	go nsInformer.Run(stopCh)
+	if !cache.WaitForNamedCacheSync("namespaces", stopCh, nsInformer.HasSynced) {
+		log.Fatal("error syncing namespaces cache")
+	}
+
+	// ConfigFreezes hold the changes to the CustomConfigs during their
+	// windows, they must be known before the CustomConfigs are synced not to
+	// apply the changes they hold meanwhile
+	freezeInformer := v1.NewConfigFreezeInformer(customconfigClient, 0, cache.Indexers{})
+	freezeQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	freezeDeletedItems := &controller.DeletedItems{
+		M: map[string]interface{}{},
+	}
+	freezeInformer.AddEventHandler(controller.NewEventHandler(freezeQueue, freezeDeletedItems))
+	ccHandler.Freezes = listers.NewConfigFreezeLister(freezeInformer.GetIndexer())
+	freezeHandler := &handler.FreezeHandler{
+		CC:                    ccHandler,
+		CCClient:              customconfigClient,
+		Recorder:              recorder,
+		Enqueue:               ccController.Enqueue,
+		EnqueueClusterConfigs: func() { enqueueAll(nil) },
+	}
+	freezeController := controller.New("config-freeze-controller", client, freezeInformer, freezeQueue, freezeHandler, freezeDeletedItems)
+	freezeHandler.Requeue = freezeController.EnqueueAfter
+	go freezeController.Run(stopCh)
+	if !cache.WaitForNamedCacheSync("configfreezes", stopCh, freezeInformer.HasSynced) {
+		log.Fatal("error syncing configfreezes cache")
+	}
+
+	// the control config map pauses the operator through its annotation,
+	// every resource is synced again when that changes
+	controlInformer := coreinformers.NewFilteredConfigMapInformer(client, controlNamespace, 0, cache.Indexers{}, func(options *meta_v1.ListOptions) {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", *controlConfigMap).String()
+	})
+	controlLister := corelisters.NewConfigMapLister(controlInformer.GetIndexer())
+	paused := func() bool {
+		cm, err := controlLister.ConfigMaps(controlNamespace).Get(*controlConfigMap)
+		return err == nil && handler.IsPaused(cm)
+	}
+	ccHandler.Paused = paused
+	resyncAll := func(reason string) {
+		log.Infof("%s, syncing every resource again", reason)
+		ccs, err := ccHandler.Lister.List(labels.Everything())
+		if err != nil {
+			log.Error("error is", err)
+			return
+		}
+		for _, cc := range ccs {
+			ccController.Enqueue(cc.Namespace + "/" + cc.Name)
+		}
+		enqueueAll(nil)
+		enqueueSchemas(nil)
+	}
+	// the deletions deferred while paused are carried out first, not to
+	// undo what the resources created since then write
+	pauseChanged := func() {
+		if !paused() {
+			ccHandler.ResumeDeletes()
+		}
+		resyncAll(fmt.Sprintf("operator paused: %v", paused()))
+	}
+	controlInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { pauseChanged() },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if handler.IsPaused(oldObj.(*core_v1.ConfigMap)) != handler.IsPaused(newObj.(*core_v1.ConfigMap)) {
+				pauseChanged()
+			}
+		},
+		DeleteFunc: func(obj interface{}) { pauseChanged() },
+	})
+	go controlInformer.Run(stopCh)
+	if !cache.WaitForNamedCacheSync("control", stopCh, controlInformer.HasSynced) {
+		log.Fatal("error syncing control config map cache")
+	}
+
+	// the signing keys config map lists the namespaces whose CustomConfigs
+	// must be signed, every CustomConfig is synced again when it changes
+	signingInformer := coreinformers.NewFilteredConfigMapInformer(client, controlNamespace, 0, cache.Indexers{}, func(options *meta_v1.ListOptions) {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", *signingKeysConfigMap).String()
+	})
+	signingLister := corelisters.NewConfigMapLister(signingInformer.GetIndexer())
+	ccHandler.TrustedKeys = func(ns string) ([]ed25519.PublicKey, bool) {
+		cm, err := signingLister.ConfigMaps(controlNamespace).Get(*signingKeysConfigMap)
+		if err != nil {
+			return nil, false
+		}
+		data, required := cm.Data[ns]
+		if !required {
+			return nil, false
+		}
+		keys, err := signature.ParsePublicKeys(data)
+		if err != nil {
+			// no key is trusted then, rather than none being required
+			log.Errorf("trusted keys of namespace %s: %v", ns, err)
+		}
+		return keys, true
+	}
+	signingInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { resyncAll("signing keys added") },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if !reflect.DeepEqual(oldObj.(*core_v1.ConfigMap).Data, newObj.(*core_v1.ConfigMap).Data) {
+				resyncAll("signing keys changed")
+			}
+		},
+		DeleteFunc: func(obj interface{}) { resyncAll("signing keys removed") },
+	})
+	go signingInformer.Run(stopCh)
+	if !cache.WaitForNamedCacheSync("signing keys", stopCh, signingInformer.HasSynced) {
+		log.Fatal("error syncing signing keys config map cache")
+	}
+
+	// every resource is synced again when a CustomConfigPolicy changes
+	policyInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { resyncAll("custom config policy added") },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if !reflect.DeepEqual(oldObj.(*customconfigv1.CustomConfigPolicy).Spec, newObj.(*customconfigv1.CustomConfigPolicy).Spec) {
+				resyncAll("custom config policy changed")
+			}
+		},
+		DeleteFunc: func(obj interface{}) { resyncAll("custom config policy removed") },
+	})
+
+	// the CustomConfigDefaults are only needed to default CustomConfigs,
+	// and then to verify the signatures made before the defaults were filled
+	var defaulter *webhook.Defaulter
+	if *webhookAddr != "" {
+		defaultsInformer := v1.NewCustomConfigDefaultsInformer(customconfigClient, meta_v1.NamespaceAll, 0, cache.Indexers{})
+		go defaultsInformer.Run(stopCh)
+		if !cache.WaitForNamedCacheSync("customconfigdefaults", stopCh, defaultsInformer.HasSynced) {
+			log.Fatal("error syncing customconfigdefaults cache")
+		}
+		defaulter = &webhook.Defaulter{
+			Lister: listers.NewCustomConfigDefaultsLister(defaultsInformer.GetIndexer()),
+			Defaults: customconfigv1.CustomConfigDefaultsSpec{
+				ConfigmapName:   *defaultConfigmapName,
+				TargetNamespace: *defaultTargetNamespace,
+				DeletionPolicy:  customconfigv1.DeletionPolicy(*defaultDeletionPolicy),
+			},
+		}
+		ccHandler.Defaults = defaulter.Effective
+	}
+
+	// run the controller loops to process items
 	go ccController.Run(stopCh)
+	go cccController.Run(stopCh)
+
+	// the webhooks reject the custom configs the operator would fail to apply
+	if *webhookAddr != "" {
+		hosts := []string{
+			*webhookService,
+			*webhookService + "." + controlNamespace,
+			*webhookService + "." + controlNamespace + ".svc",
+			"localhost",
+			"127.0.0.1",
+		}
+		cert, ca, err := webhook.Certificate(*webhookCertDir, hosts, *webhookSelfSigned)
+		if err != nil {
+			log.Fatalf("loading webhook certificate: %v", err)
+		}
+		if *webhookSelfSigned && len(ca) > 0 {
+			if err = webhook.InjectCABundle(client, *webhookConfig, ca); err != nil {
+				log.Error("error is", err)
+			}
+			if err = webhook.InjectConversionCABundle(client, "customconfigs.mtcil.com", ca); err != nil {
+				log.Error("error is", err)
+			}
+		}
+
+		server := &webhook.Server{
+			Validator: &webhook.Validator{
+				Lister:    ccHandler.Lister,
+				Schemas:   schemaLister,
+				Decrypter: decrypter,
+				Policy:    policy,
+				Policies:  ccHandler.Policies,
+			},
+			Defaulter: defaulter,
+			Approver: &webhook.Approver{
+				Namespaces:   namespaceLister,
+				OperatorUser: fmt.Sprintf("system:serviceaccount:%s:%s", controlNamespace, *serviceAccount),
+			},
+			Converter: &webhook.Converter{},
+		}
+		go server.Run(*webhookAddr, cert, stopCh)
+	}
 
 	// use a channel to handle OS signals to terminate and gracefully shut
 	// down processing
@@ -152,3 +522,48 @@ func main() {
 	signal.Notify(sigTerm, syscall.SIGINT)
 	<-sigTerm
 }
+
+// decryptionKeySecretKey is the key of the secret of --decryption-key-secret
+// holding the private key
+const decryptionKeySecretKey = "private.key"
+
+// loadDecryptionKey loads the private key of the operator from keyFile, or
+// else from the secret of namespace ns, nil when neither is set
+func loadDecryptionKey(client kubernetes.Interface, ns, keyFile, secretName string) *encryption.Key {
+	var data string
+	switch {
+	case keyFile != "":
+		content, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			log.Fatalf("reading decryption key: %v", err)
+		}
+		data = string(content)
+	case secretName != "":
+		secret, err := client.CoreV1().Secrets(ns).Get(secretName, meta_v1.GetOptions{})
+		if err != nil {
+			log.Fatalf("reading decryption key: %v", err)
+		}
+		data = string(secret.Data[decryptionKeySecretKey])
+	default:
+		log.Info("no decryption key, encrypted values are not written")
+		return nil
+	}
+
+	key, err := encryption.ParseKey(data)
+	if err != nil {
+		log.Fatalf("loading decryption key: %v", err)
+	}
+	log.Infof("values are to be encrypted with public key %s", key.PublicKey())
+	return key
+}
+
+// splitList splits a comma separated flag, dropping empty elements
+func splitList(s string) []string {
+	var list []string
+	for _, e := range strings.Split(s, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			list = append(list, e)
+		}
+	}
+	return list
+}
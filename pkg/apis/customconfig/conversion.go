@@ -0,0 +1,17 @@
+package customconfig
+
+import "k8s.io/apimachinery/pkg/runtime"
+
+// Hub is the version of a kind every other version of it converts to and
+// from, so that each version only converts to and from the hub
+type Hub interface {
+	runtime.Object
+	Hub()
+}
+
+// Convertible is a version of a kind which converts to and from its Hub
+type Convertible interface {
+	runtime.Object
+	ConvertTo(dst Hub) error
+	ConvertFrom(src Hub) error
+}
@@ -0,0 +1,300 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig"
+	v2 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v2"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TargetLabelsAnnotation keeps the labels of the target of a v2 CustomConfig,
+// as a JSON object, which v1 has no field for
+const TargetLabelsAnnotation = "mtcil.com/target-labels"
+
+// ConvertTo converts cc to the v2 hub
+func (cc *CustomConfig) ConvertTo(dst customconfig.Hub) error {
+	hub, ok := dst.(*v2.CustomConfig)
+	if !ok {
+		return fmt.Errorf("cannot convert customconfig to %T", dst)
+	}
+
+	hub.ObjectMeta = *cc.ObjectMeta.DeepCopy()
+	spec := &cc.Spec
+	hub.Spec = v2.CustomConfigSpec{
+		Target: v2.Target{
+			Namespace:     spec.TargetNamespace,
+			ConfigMapName: spec.ConfigmapName,
+			SecretName:    spec.SecretName,
+		},
+		Key:            spec.Key,
+		Value:          spec.Value,
+		EncryptedValue: spec.EncryptedValue,
+		Type:           v2.ValueType(spec.Type),
+		Strategy:       v2.Strategy(spec.Strategy),
+		Priority:       spec.Priority,
+		RestartPolicy:  v2.RestartPolicy(spec.RestartPolicy),
+		Suspend:        spec.Suspend,
+		DryRun:         spec.DryRun,
+		DeletionPolicy: v2.DeletionPolicy(spec.DeletionPolicy),
+	}
+	if labels, ok := TargetLabels(cc); ok {
+		hub.Spec.Target.Labels = labels
+		delete(hub.Annotations, TargetLabelsAnnotation)
+		if len(hub.Annotations) == 0 {
+			hub.Annotations = nil
+		}
+	}
+	if spec.Immutable != nil {
+		hub.Spec.Target.Immutable = &v2.ImmutableSpec{
+			Selector: spec.Immutable.Selector.DeepCopy(),
+			Retain:   copyInt32(spec.Immutable.Retain),
+		}
+	}
+	if spec.ValueFrom != nil {
+		hub.Spec.ValueFrom = &v2.ValueSource{}
+		if ext := spec.ValueFrom.External; ext != nil {
+			hub.Spec.ValueFrom.External = &v2.ExternalSource{PollInterval: copyDuration(ext.PollInterval)}
+			if ext.File != nil {
+				hub.Spec.ValueFrom.External.File = &v2.FileSource{Path: ext.File.Path}
+			}
+			if ext.HTTP != nil {
+				hub.Spec.ValueFrom.External.HTTP = &v2.HTTPSource{URL: ext.HTTP.URL, JSONPath: ext.HTTP.JSONPath}
+			}
+			if ext.Vault != nil {
+				hub.Spec.ValueFrom.External.Vault = &v2.VaultSource{
+					Address:        ext.Vault.Address,
+					Mount:          ext.Vault.Mount,
+					Path:           ext.Vault.Path,
+					Key:            ext.Vault.Key,
+					Version:        ext.Vault.Version,
+					TokenSecretRef: ext.Vault.TokenSecretRef.DeepCopy(),
+				}
+			}
+		}
+	}
+	if spec.Constraints != nil {
+		hub.Spec.Constraints = &v2.ValueConstraints{
+			Minimum: spec.Constraints.Minimum,
+			Maximum: spec.Constraints.Maximum,
+			Enum:    copyStrings(spec.Constraints.Enum),
+			Pattern: spec.Constraints.Pattern,
+		}
+	}
+	if spec.File != nil {
+		hub.Spec.File = &v2.FileSpec{Name: spec.File.Name, Format: v2.FileFormat(spec.File.Format)}
+	}
+	if spec.Rollout != nil {
+		hub.Spec.Rollout = &v2.RolloutSpec{Window: copyDuration(spec.Rollout.Window)}
+	}
+	if spec.RevisionHistoryLimit != nil || spec.RollbackTo != nil {
+		hub.Spec.History = &v2.HistorySpec{
+			Limit:      copyInt32(spec.RevisionHistoryLimit),
+			RollbackTo: copyInt64(spec.RollbackTo),
+		}
+	}
+	if spec.ActiveFrom != nil || spec.ActiveUntil != nil || spec.Schedule != nil {
+		hub.Spec.Activity = &v2.ActivitySpec{
+			From:  spec.ActiveFrom.DeepCopy(),
+			Until: spec.ActiveUntil.DeepCopy(),
+		}
+		if spec.Schedule != nil {
+			hub.Spec.Activity.Schedule = &v2.ScheduleSpec{Cron: spec.Schedule.Cron, Duration: spec.Schedule.Duration}
+		}
+	}
+
+	status := &cc.Status
+	hub.Status = v2.CustomConfigStatus{
+		AppliedValue:         status.AppliedValue,
+		AppliedGeneration:    status.AppliedGeneration,
+		RolledBackGeneration: status.RolledBackGeneration,
+		ApprovedGeneration:   status.ApprovedGeneration,
+		ApprovedBy:           status.ApprovedBy,
+		CurrentRevision:      status.CurrentRevision,
+		Override:             status.Override,
+		DryRunDiff:           status.DryRunDiff,
+		NextTransitionTime:   status.NextTransitionTime.DeepCopy(),
+	}
+	for _, r := range status.Revisions {
+		hub.Status.Revisions = append(hub.Status.Revisions, v2.Revision{
+			Revision:          r.Revision,
+			Name:              r.Name,
+			CreationTimestamp: r.CreationTimestamp,
+		})
+	}
+	for _, c := range status.Conditions {
+		hub.Status.Conditions = append(hub.Status.Conditions, v2.CustomConfigCondition{
+			Type:               v2.ConditionType(c.Type),
+			Status:             c.Status,
+			LastTransitionTime: c.LastTransitionTime,
+			Reason:             c.Reason,
+			Message:            c.Message,
+		})
+	}
+	return nil
+}
+
+// ConvertFrom converts the v2 hub src to cc
+func (cc *CustomConfig) ConvertFrom(src customconfig.Hub) error {
+	hub, ok := src.(*v2.CustomConfig)
+	if !ok {
+		return fmt.Errorf("cannot convert customconfig from %T", src)
+	}
+
+	cc.ObjectMeta = *hub.ObjectMeta.DeepCopy()
+	spec := &hub.Spec
+	cc.Spec = CustomConfigSpec{
+		Key:             spec.Key,
+		Value:           spec.Value,
+		EncryptedValue:  spec.EncryptedValue,
+		Type:            ValueType(spec.Type),
+		ConfigmapName:   spec.Target.ConfigMapName,
+		TargetNamespace: spec.Target.Namespace,
+		SecretName:      spec.Target.SecretName,
+		Strategy:        Strategy(spec.Strategy),
+		Priority:        spec.Priority,
+		RestartPolicy:   RestartPolicy(spec.RestartPolicy),
+		Suspend:         spec.Suspend,
+		DryRun:          spec.DryRun,
+		DeletionPolicy:  DeletionPolicy(spec.DeletionPolicy),
+	}
+	if spec.Target.Labels != nil {
+		labels, err := json.Marshal(spec.Target.Labels)
+		if err != nil {
+			return err
+		}
+		if cc.Annotations == nil {
+			cc.Annotations = map[string]string{}
+		}
+		cc.Annotations[TargetLabelsAnnotation] = string(labels)
+	}
+	if spec.Target.Immutable != nil {
+		cc.Spec.Immutable = &ImmutableSpec{
+			Selector: spec.Target.Immutable.Selector.DeepCopy(),
+			Retain:   copyInt32(spec.Target.Immutable.Retain),
+		}
+	}
+	if spec.ValueFrom != nil {
+		cc.Spec.ValueFrom = &ValueSource{}
+		if ext := spec.ValueFrom.External; ext != nil {
+			cc.Spec.ValueFrom.External = &ExternalSource{PollInterval: copyDuration(ext.PollInterval)}
+			if ext.File != nil {
+				cc.Spec.ValueFrom.External.File = &FileSource{Path: ext.File.Path}
+			}
+			if ext.HTTP != nil {
+				cc.Spec.ValueFrom.External.HTTP = &HTTPSource{URL: ext.HTTP.URL, JSONPath: ext.HTTP.JSONPath}
+			}
+			if ext.Vault != nil {
+				cc.Spec.ValueFrom.External.Vault = &VaultSource{
+					Address:        ext.Vault.Address,
+					Mount:          ext.Vault.Mount,
+					Path:           ext.Vault.Path,
+					Key:            ext.Vault.Key,
+					Version:        ext.Vault.Version,
+					TokenSecretRef: ext.Vault.TokenSecretRef.DeepCopy(),
+				}
+			}
+		}
+	}
+	if spec.Constraints != nil {
+		cc.Spec.Constraints = &ValueConstraints{
+			Minimum: spec.Constraints.Minimum,
+			Maximum: spec.Constraints.Maximum,
+			Enum:    copyStrings(spec.Constraints.Enum),
+			Pattern: spec.Constraints.Pattern,
+		}
+	}
+	if spec.File != nil {
+		cc.Spec.File = &FileSpec{Name: spec.File.Name, Format: FileFormat(spec.File.Format)}
+	}
+	if spec.Rollout != nil {
+		cc.Spec.Rollout = &RolloutSpec{Window: copyDuration(spec.Rollout.Window)}
+	}
+	if spec.History != nil {
+		cc.Spec.RevisionHistoryLimit = copyInt32(spec.History.Limit)
+		cc.Spec.RollbackTo = copyInt64(spec.History.RollbackTo)
+	}
+	if spec.Activity != nil {
+		cc.Spec.ActiveFrom = spec.Activity.From.DeepCopy()
+		cc.Spec.ActiveUntil = spec.Activity.Until.DeepCopy()
+		if spec.Activity.Schedule != nil {
+			cc.Spec.Schedule = &ScheduleSpec{Cron: spec.Activity.Schedule.Cron, Duration: spec.Activity.Schedule.Duration}
+		}
+	}
+
+	status := &hub.Status
+	cc.Status = CustomConfigStatus{
+		AppliedValue:         status.AppliedValue,
+		AppliedGeneration:    status.AppliedGeneration,
+		RolledBackGeneration: status.RolledBackGeneration,
+		ApprovedGeneration:   status.ApprovedGeneration,
+		ApprovedBy:           status.ApprovedBy,
+		CurrentRevision:      status.CurrentRevision,
+		Override:             status.Override,
+		DryRunDiff:           status.DryRunDiff,
+		NextTransitionTime:   status.NextTransitionTime.DeepCopy(),
+	}
+	for _, r := range status.Revisions {
+		cc.Status.Revisions = append(cc.Status.Revisions, Revision{
+			Revision:          r.Revision,
+			Name:              r.Name,
+			CreationTimestamp: r.CreationTimestamp,
+		})
+	}
+	for _, c := range status.Conditions {
+		cc.Status.Conditions = append(cc.Status.Conditions, CustomConfigCondition{
+			Type:               ConditionType(c.Type),
+			Status:             c.Status,
+			LastTransitionTime: c.LastTransitionTime,
+			Reason:             c.Reason,
+			Message:            c.Message,
+		})
+	}
+	return nil
+}
+
+// TargetLabels returns the labels of the target of cc kept in its
+// annotation, and whether the annotation holds any
+func TargetLabels(cc *CustomConfig) (map[string]string, bool) {
+	annotation, ok := cc.Annotations[TargetLabelsAnnotation]
+	if !ok {
+		return nil, false
+	}
+	labels := map[string]string{}
+	if err := json.Unmarshal([]byte(annotation), &labels); err != nil {
+		return nil, false
+	}
+	return labels, true
+}
+
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string{}, s...)
+}
+
+func copyInt32(i *int32) *int32 {
+	if i == nil {
+		return nil
+	}
+	c := *i
+	return &c
+}
+
+func copyInt64(i *int64) *int64 {
+	if i == nil {
+		return nil
+	}
+	c := *i
+	return &c
+}
+
+func copyDuration(d *meta_v1.Duration) *meta_v1.Duration {
+	if d == nil {
+		return nil
+	}
+	c := *d
+	return &c
+}
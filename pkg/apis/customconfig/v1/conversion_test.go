@@ -0,0 +1,105 @@
+package v1
+
+import (
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	v2 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/diff"
+)
+
+// fuzzRounds is the number of random CustomConfigs converted each way
+const fuzzRounds = 1000
+
+// newFuzzer fills every field of both versions, nil or not, but for the
+// empty history and activity of v2, which v1 has no field to keep and which
+// mean the same as none
+func newFuzzer(seed int64) *fuzz.Fuzzer {
+	return fuzz.NewWithSeed(seed).NilChance(0.2).NumElements(0, 3).Funcs(
+		func(h *v2.HistorySpec, c fuzz.Continue) {
+			for h.Limit == nil && h.RollbackTo == nil {
+				c.FuzzNoCustom(h)
+			}
+		},
+		func(a *v2.ActivitySpec, c fuzz.Continue) {
+			for a.From == nil && a.Until == nil && a.Schedule == nil {
+				c.FuzzNoCustom(a)
+			}
+		},
+	)
+}
+
+func TestRoundTripV1(t *testing.T) {
+	f := newFuzzer(1)
+	for i := 0; i < fuzzRounds; i++ {
+		original := &CustomConfig{}
+		f.Fuzz(original)
+
+		hub := &v2.CustomConfig{}
+		if err := original.DeepCopy().ConvertTo(hub); err != nil {
+			t.Fatalf("converting to v2: %v", err)
+		}
+		converted := &CustomConfig{}
+		if err := converted.ConvertFrom(hub); err != nil {
+			t.Fatalf("converting from v2: %v", err)
+		}
+		// the TypeMeta is set by the serializer of each version
+		converted.TypeMeta = original.TypeMeta
+
+		if !equality.Semantic.DeepEqual(original, converted) {
+			t.Fatalf("v1 -> v2 -> v1 changed the customconfig:\n%s", diff.ObjectReflectDiff(original, converted))
+		}
+	}
+}
+
+func TestRoundTripV2(t *testing.T) {
+	f := newFuzzer(2)
+	for i := 0; i < fuzzRounds; i++ {
+		original := &v2.CustomConfig{}
+		f.Fuzz(original)
+
+		spoke := &CustomConfig{}
+		if err := spoke.ConvertFrom(original.DeepCopy()); err != nil {
+			t.Fatalf("converting from v2: %v", err)
+		}
+		converted := &v2.CustomConfig{}
+		if err := spoke.ConvertTo(converted); err != nil {
+			t.Fatalf("converting to v2: %v", err)
+		}
+		converted.TypeMeta = original.TypeMeta
+
+		if !equality.Semantic.DeepEqual(original, converted) {
+			t.Fatalf("v2 -> v1 -> v2 changed the customconfig:\n%s", diff.ObjectReflectDiff(original, converted))
+		}
+	}
+}
+
+func TestTargetLabelsAnnotation(t *testing.T) {
+	original := &v2.CustomConfig{}
+	original.Name = "cc"
+	original.Spec.Target.Labels = map[string]string{"app": "checkout"}
+
+	spoke := &CustomConfig{}
+	if err := spoke.ConvertFrom(original.DeepCopy()); err != nil {
+		t.Fatalf("converting from v2: %v", err)
+	}
+	if got := spoke.Annotations[TargetLabelsAnnotation]; got != `{"app":"checkout"}` {
+		t.Fatalf("annotation %s is %q", TargetLabelsAnnotation, got)
+	}
+	labels, ok := TargetLabels(spoke)
+	if !ok || labels["app"] != "checkout" {
+		t.Fatalf("target labels are %v", labels)
+	}
+
+	converted := &v2.CustomConfig{}
+	if err := spoke.ConvertTo(converted); err != nil {
+		t.Fatalf("converting to v2: %v", err)
+	}
+	if _, ok := converted.Annotations[TargetLabelsAnnotation]; ok {
+		t.Fatalf("annotation %s is left in v2", TargetLabelsAnnotation)
+	}
+	if !equality.Semantic.DeepEqual(original.Spec.Target, converted.Spec.Target) {
+		t.Fatalf("v2 -> v1 -> v2 changed the target:\n%s", diff.ObjectReflectDiff(original.Spec.Target, converted.Spec.Target))
+	}
+}
@@ -26,12 +26,26 @@ func Resource(resource string) schema.GroupResource {
 }
 
 // addKnownTypes adds our types to the API scheme by registering
-// CustomConfig and CustomConfigList
+// CustomConfig, ClusterCustomConfig, CustomConfigOverride,
+// CustomConfigDefaults, ConfigSchema, ConfigFreeze, CustomConfigPolicy and
+// their lists
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(
 		SchemeGroupVersion,
 		&CustomConfig{},
 		&CustomConfigList{},
+		&ClusterCustomConfig{},
+		&ClusterCustomConfigList{},
+		&CustomConfigOverride{},
+		&CustomConfigOverrideList{},
+		&CustomConfigDefaults{},
+		&CustomConfigDefaultsList{},
+		&ConfigSchema{},
+		&ConfigSchemaList{},
+		&ConfigFreeze{},
+		&ConfigFreezeList{},
+		&CustomConfigPolicy{},
+		&CustomConfigPolicyList{},
 	)
 
 	// register the type in the scheme
@@ -1,18 +1,19 @@
 package v1
 
 import (
+	core_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 type CustomConfig struct {
 	meta_v1.TypeMeta   `json:",inline"`
 	meta_v1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec CustomConfigSpec `json:"spec"`
+	Spec   CustomConfigSpec   `json:"spec"`
+	Status CustomConfigStatus `json:"status,omitempty"`
 }
 
 // CustomConfigSpec is the spec for a CustomConfig resource
@@ -20,6 +21,339 @@ type CustomConfigSpec struct {
 	Key           string `json:"key"`
 	Value         string `json:"value"`
 	ConfigmapName string `json:"configmapName,omitempty"`
+
+	// ValueFrom, when set, takes the value from a source outside of the
+	// CustomConfig instead of Value
+	ValueFrom *ValueSource `json:"valueFrom,omitempty"`
+	// EncryptedValue, when set, is the value sealed with the public key of
+	// the operator, e.g. by crd-operator encrypt, which decrypts it and
+	// writes it to the Secret SecretName instead of Value
+	EncryptedValue string `json:"encryptedValue,omitempty"`
+
+	// Type, when set, is the type Value must have, Value is normalized to
+	// the canonical form of its type before it is written
+	Type ValueType `json:"type,omitempty"`
+	// Constraints, when set, further restrict the values of a typed Value
+	Constraints *ValueConstraints `json:"constraints,omitempty"`
+
+	// TargetNamespace is the namespace of the ConfigMap, the namespace of
+	// the operator when not set. Any other namespace than these two and the
+	// one of the CustomConfig is only written when the user who last changed
+	// the CustomConfig may write the ConfigMap there.
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+	// SecretName is the Secret of the target namespace EncryptedValue is
+	// written to, the ConfigMap is not written then
+	SecretName string `json:"secretName,omitempty"`
+
+	// File, when set, makes Key/Value an entry of a structured file stored
+	// under a single ConfigMap key instead of a ConfigMap key of its own
+	File *FileSpec `json:"file,omitempty"`
+
+	// Strategy selects how Value is written to Key, Replace by default
+	Strategy Strategy `json:"strategy,omitempty"`
+	// Priority orders the fragments merged into the same key, fragments
+	// with a higher priority are merged later and win conflicts
+	Priority int32 `json:"priority,omitempty"`
+
+	// Immutable, when set, publishes the ConfigMap as immutable generations
+	// named <configmapName>-<contenthash> instead of updating it in place
+	Immutable *ImmutableSpec `json:"immutable,omitempty"`
+
+	// RestartPolicy tells how the workloads consuming the ConfigMap are
+	// restarted when this CustomConfig changes it, the operator's default
+	// policy applies when not set
+	RestartPolicy RestartPolicy `json:"restartPolicy,omitempty"`
+
+	// Rollout, when set, watches the rollout of the Deployments consuming
+	// the ConfigMap after a change and reverts the change when they do not
+	// become healthy
+	Rollout *RolloutSpec `json:"rollout,omitempty"`
+
+	// RevisionHistoryLimit is the number of applied values kept as
+	// ControllerRevisions, 10 when not set
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+	// RollbackTo, when set, sets Value back to the one of that revision,
+	// after which it is cleared. A value taken from ValueFrom is pinned,
+	// ValueFrom is cleared. An encrypted value keeps no revisions and is not
+	// rolled back.
+	RollbackTo *int64 `json:"rollbackTo,omitempty"`
+
+	// ActiveFrom, when set, is when the key is set, it is not before
+	ActiveFrom *meta_v1.Time `json:"activeFrom,omitempty"`
+	// ActiveUntil, when set, is when the key is removed
+	ActiveUntil *meta_v1.Time `json:"activeUntil,omitempty"`
+	// Schedule, when set, only sets the key during the windows it opens,
+	// within ActiveFrom and ActiveUntil
+	Schedule *ScheduleSpec `json:"schedule,omitempty"`
+
+	// Suspend stops writing the ConfigMap for this CustomConfig, which only
+	// reports in its status whether the ConfigMap drifted from it
+	Suspend bool `json:"suspend,omitempty"`
+	// DryRun stops writing the ConfigMap for this CustomConfig, the change
+	// it would make is recorded as a unified diff in its status and events
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// DeletionPolicy tells what happens to the key when this CustomConfig
+	// is deleted, Delete by default
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// ValueSource is where the value of a CustomConfig comes from
+type ValueSource struct {
+	// External fetches the value from outside of Kubernetes
+	External *ExternalSource `json:"external,omitempty"`
+}
+
+// ExternalSource fetches a value from exactly one of a file, an HTTP(S) URL
+// or a Vault-compatible KV store
+type ExternalSource struct {
+	File  *FileSource  `json:"file,omitempty"`
+	HTTP  *HTTPSource  `json:"http,omitempty"`
+	Vault *VaultSource `json:"vault,omitempty"`
+	// PollInterval is how often the value is fetched again, 1m when not set
+	PollInterval *meta_v1.Duration `json:"pollInterval,omitempty"`
+}
+
+// FileSource reads the value from a file of the operator
+type FileSource struct {
+	// Path is the path of the file, e.g. of a mounted volume
+	Path string `json:"path"`
+}
+
+// HTTPSource fetches the value from an HTTP(S) URL
+type HTTPSource struct {
+	URL string `json:"url"`
+	// JSONPath, when set, extracts the value from the JSON response, e.g.
+	// {.flags.checkout.enabled}, else the whole response is the value
+	JSONPath string `json:"jsonPath,omitempty"`
+}
+
+// VaultSource reads the value from a key of a secret of a Vault-compatible
+// KV secrets engine
+type VaultSource struct {
+	// Address is the base URL of the server, e.g. https://vault:8200
+	Address string `json:"address"`
+	// Mount is where the KV engine is mounted, secret when empty
+	Mount string `json:"mount,omitempty"`
+	// Path is the path of the secret within the engine
+	Path string `json:"path"`
+	// Key is the key of the secret holding the value
+	Key string `json:"key"`
+	// Version is the version of the KV engine, 1 or 2, 2 when not set
+	Version int32 `json:"version,omitempty"`
+	// TokenSecretRef selects the key of a Secret of the namespace of the
+	// CustomConfig holding the token of the requests
+	TokenSecretRef *core_v1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+}
+
+// ValueType is the type of the value of a CustomConfig
+type ValueType string
+
+const (
+	ValueString   ValueType = "string"
+	ValueInt      ValueType = "int"
+	ValueBool     ValueType = "bool"
+	ValueDuration ValueType = "duration"
+	ValueJSON     ValueType = "json"
+	ValueYAML     ValueType = "yaml"
+)
+
+// ValueConstraints restrict the values of a typed CustomConfig
+type ValueConstraints struct {
+	// Minimum and Maximum bound an int or duration value, e.g. 30s, or the
+	// length of a string value
+	Minimum string `json:"minimum,omitempty"`
+	Maximum string `json:"maximum,omitempty"`
+	// Enum lists the values allowed, compared once normalized
+	Enum []string `json:"enum,omitempty"`
+	// Pattern is a regular expression the normalized value must match
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// DeletionPolicy is what happens to the key of a deleted CustomConfig
+type DeletionPolicy string
+
+const (
+	// DeletionDelete removes the key, and deletes the ConfigMap once it is
+	// empty provided every key it had was written by the operator
+	DeletionDelete DeletionPolicy = "Delete"
+	// DeletionRetain leaves the key and the ConfigMap as they are
+	DeletionRetain DeletionPolicy = "Retain"
+	// DeletionRetainConfigMap removes the key but never deletes the ConfigMap
+	DeletionRetainConfigMap DeletionPolicy = "RetainConfigMap"
+)
+
+// ScheduleSpec opens recurring windows during which the key is set
+type ScheduleSpec struct {
+	// Cron is the standard cron expression of the start of the windows,
+	// e.g. "0 2 * * *", which may be prefixed with CRON_TZ=<zone>
+	Cron string `json:"cron"`
+	// Duration is how long each window lasts
+	Duration meta_v1.Duration `json:"duration"`
+}
+
+// RolloutSpec configures the health-gated rollout of a change
+type RolloutSpec struct {
+	// Window is how long the consumers have to become healthy, 5m when not set
+	Window *meta_v1.Duration `json:"window,omitempty"`
+}
+
+// RestartPolicy is the way the workloads consuming a ConfigMap are restarted
+type RestartPolicy string
+
+const (
+	// RestartImmediate rolls the consumers as soon as the ConfigMap changes
+	RestartImmediate RestartPolicy = "Immediate"
+	// RestartDebounced rolls the consumers once the ConfigMap has stopped
+	// changing for a while, so that a burst of changes rolls them once
+	RestartDebounced RestartPolicy = "Debounced"
+	// RestartManual never rolls the consumers, opting out of restarts
+	RestartManual RestartPolicy = "Manual"
+)
+
+// ImmutableSpec selects the workloads to point at the latest generation of
+// the ConfigMap and how many older generations to keep
+type ImmutableSpec struct {
+	// Selector selects the Deployments and StatefulSets whose references to
+	// the ConfigMap are rewritten, all of them when not set
+	Selector *meta_v1.LabelSelector `json:"selector,omitempty"`
+	// Retain is the number of older generations kept, 3 when not set
+	Retain *int32 `json:"retain,omitempty"`
+}
+
+// Strategy is the way a CustomConfig writes its value to the ConfigMap key
+type Strategy string
+
+const (
+	// StrategyReplace sets the key to the value
+	StrategyReplace Strategy = "Replace"
+	// StrategyMerge deep-merges the value, a JSON or YAML fragment, with
+	// the fragments of every other CustomConfig merging into the key
+	StrategyMerge Strategy = "Merge"
+)
+
+// FileFormat is the format a file key of the ConfigMap is rendered in
+type FileFormat string
+
+const (
+	FormatProperties FileFormat = "properties"
+	FormatDotenv     FileFormat = "dotenv"
+	FormatJSON       FileFormat = "json"
+	FormatYAML       FileFormat = "yaml"
+	FormatTOML       FileFormat = "toml"
+	FormatINI        FileFormat = "ini"
+)
+
+// FileSpec names the ConfigMap key holding the rendered file and its format
+type FileSpec struct {
+	// Name is the ConfigMap key, e.g. application.properties
+	Name string `json:"name"`
+	// Format defaults to the one implied by the extension of Name
+	Format FileFormat `json:"format,omitempty"`
+}
+
+// CustomConfigStatus is the status for a CustomConfig resource
+type CustomConfigStatus struct {
+	// AppliedValue is the value last written to the ConfigMap
+	AppliedValue string `json:"appliedValue,omitempty"`
+	// AppliedGeneration is the generation AppliedValue comes from
+	AppliedGeneration int64 `json:"appliedGeneration,omitempty"`
+	// RolledBackGeneration is the last generation whose rollout failed and
+	// was reverted, it is not applied again
+	RolledBackGeneration int64 `json:"rolledBackGeneration,omitempty"`
+	// ApprovedGeneration is the last generation approved in a namespace
+	// requiring approval, the value of a later one is not applied until it
+	// is approved
+	ApprovedGeneration int64 `json:"approvedGeneration,omitempty"`
+	// ApprovedBy is the user who approved ApprovedGeneration
+	ApprovedBy string `json:"approvedBy,omitempty"`
+
+	// CurrentRevision is the revision of AppliedValue
+	CurrentRevision int64 `json:"currentRevision,omitempty"`
+	// Revisions lists the revisions kept, oldest first
+	Revisions []Revision `json:"revisions,omitempty"`
+
+	// Override is the name of the CustomConfigOverride whose value is
+	// currently written instead of Value
+	Override string `json:"override,omitempty"`
+
+	// DryRunDiff is the unified diff of the change to the ConfigMap a dry
+	// run would make, empty when it would change nothing
+	DryRunDiff string `json:"dryRunDiff,omitempty"`
+
+	// NextTransitionTime is when the key is next set or removed following
+	// ActiveFrom, ActiveUntil and Schedule
+	NextTransitionTime *meta_v1.Time `json:"nextTransitionTime,omitempty"`
+
+	Conditions []CustomConfigCondition `json:"conditions,omitempty"`
+}
+
+// Revision is an applied value kept as a ControllerRevision
+type Revision struct {
+	Revision int64 `json:"revision"`
+	// Name is the name of the ControllerRevision holding the value
+	Name              string       `json:"name"`
+	CreationTimestamp meta_v1.Time `json:"creationTimestamp,omitempty"`
+}
+
+// ConditionType is the type of a CustomConfigCondition
+type ConditionType string
+
+const (
+	// ConditionRolledBack is true when the rollout of the current generation
+	// failed and the previous value was restored
+	ConditionRolledBack ConditionType = "RolledBack"
+	// ConditionActive is true when the key is set, i.e. when the time is
+	// within the activity window and schedule of the CustomConfig
+	ConditionActive ConditionType = "Active"
+	// ConditionSuspended is true when the ConfigMap is not written, because
+	// of spec.suspend or because the operator is paused
+	ConditionSuspended ConditionType = "Suspended"
+	// ConditionDrifted is true when the ConfigMap of a suspended
+	// CustomConfig differs from what it would write
+	ConditionDrifted ConditionType = "Drifted"
+	// ConditionVerified is false when the namespace of the CustomConfig
+	// requires it to be signed and it has no valid signature, it is not
+	// applied then
+	ConditionVerified ConditionType = "Verified"
+	// ConditionApproved is false when the namespace of the CustomConfig
+	// requires changes to be approved and its generation is not, the value
+	// last approved stays applied then
+	ConditionApproved ConditionType = "Approved"
+	// ConditionAuthorized is false when the user who last changed the
+	// CustomConfig could not write its ConfigMap or Secret directly, it is
+	// not applied then
+	ConditionAuthorized ConditionType = "Authorized"
+	// ConditionPolicyViolation is true when the CustomConfig writes to a
+	// namespace, ConfigMap or key the policy of the operator protects, it
+	// is not applied then
+	ConditionPolicyViolation ConditionType = "PolicyViolation"
+	// ConditionDecrypted is false when EncryptedValue cannot be decrypted
+	// by the operator
+	ConditionDecrypted ConditionType = "Decrypted"
+	// ConditionValueFetched is false when the value of spec.valueFrom could
+	// not be fetched, the last value fetched is written meanwhile
+	ConditionValueFetched ConditionType = "ValueFetched"
+	// ConditionValueValid is false when the value does not have the type
+	// of the CustomConfig or breaks its constraints, or when the ConfigSchema
+	// of the ConfigMap rejects it, it is not written then
+	ConditionValueValid ConditionType = "ValueValid"
+	// ConditionSatisfied is true when a ConfigSchema has every required
+	// key provided
+	ConditionSatisfied ConditionType = "Satisfied"
+	// ConditionFrozen is true when a ConfigFreeze holds the changes to the
+	// CustomConfig, false when its emergency override annotation lets them
+	// through
+	ConditionFrozen ConditionType = "Frozen"
+)
+
+// CustomConfigCondition describes the state of a CustomConfig at a point in time
+type CustomConfigCondition struct {
+	Type               ConditionType           `json:"type"`
+	Status             core_v1.ConditionStatus `json:"status"`
+	LastTransitionTime meta_v1.Time            `json:"lastTransitionTime,omitempty"`
+	Reason             string                  `json:"reason,omitempty"`
+	Message            string                  `json:"message,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -30,3 +364,315 @@ type CustomConfigList struct {
 
 	Items []CustomConfig `json:"items"`
 }
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterCustomConfig sets a key of a ConfigMap in every namespace matching
+// its namespace selector
+type ClusterCustomConfig struct {
+	meta_v1.TypeMeta   `json:",inline"`
+	meta_v1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterCustomConfigSpec   `json:"spec"`
+	Status ClusterCustomConfigStatus `json:"status,omitempty"`
+}
+
+// ClusterCustomConfigSpec is the spec for a ClusterCustomConfig resource
+type ClusterCustomConfigSpec struct {
+	// NamespaceSelector selects the namespaces the key is set in, all of
+	// them when not set
+	NamespaceSelector *meta_v1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	Key           string `json:"key"`
+	Value         string `json:"value"`
+	ConfigmapName string `json:"configmapName"`
+
+	// Rollout, when set, rolls a change of Value out to the namespaces in
+	// batches instead of to all of them at once
+	Rollout *ProgressiveRolloutSpec `json:"rollout,omitempty"`
+}
+
+// ProgressiveRolloutSpec configures the batches a change is rolled out in
+type ProgressiveRolloutSpec struct {
+	// BatchSize is the number of namespaces updated per batch, 1 when not set
+	BatchSize int32 `json:"batchSize,omitempty"`
+	// Interval is the pause between two batches, 1m when not set
+	Interval *meta_v1.Duration `json:"interval,omitempty"`
+	// OrderLabel is the namespace label ordering the batches, the namespaces
+	// whose OrderLabel has the first value of Order are updated first, e.g.
+	// tier with [canary], and the namespaces without any of the values last
+	OrderLabel string   `json:"orderLabel,omitempty"`
+	Order      []string `json:"order,omitempty"`
+	// Paused stops the rollout after the current batch until it is unset
+	Paused bool `json:"paused,omitempty"`
+}
+
+// ClusterCustomConfigStatus is the status for a ClusterCustomConfig resource
+type ClusterCustomConfigStatus struct {
+	// Namespaces lists the namespaces the key is currently set in
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NamespaceStatuses tells the value last set in each of the Namespaces
+	NamespaceStatuses []NamespaceStatus `json:"namespaceStatuses,omitempty"`
+	// Rollout tracks the progress of the rollout of Value, when one is set
+	Rollout *ProgressiveRolloutStatus `json:"rollout,omitempty"`
+}
+
+// NamespaceStatus is the value of a ClusterCustomConfig set in a namespace
+type NamespaceStatus struct {
+	Namespace  string       `json:"namespace"`
+	Value      string       `json:"value"`
+	UpdateTime meta_v1.Time `json:"updateTime,omitempty"`
+}
+
+// RolloutPhase is the phase of the progressive rollout of a value
+type RolloutPhase string
+
+const (
+	RolloutProgressing RolloutPhase = "Progressing"
+	RolloutPaused      RolloutPhase = "Paused"
+	RolloutComplete    RolloutPhase = "Complete"
+)
+
+// ProgressiveRolloutStatus is the progress of the rollout of a value
+type ProgressiveRolloutStatus struct {
+	Phase RolloutPhase `json:"phase"`
+	// Value is the value being rolled out
+	Value string `json:"value"`
+	// UpdatedNamespaces out of TotalNamespaces have Value set
+	UpdatedNamespaces int32 `json:"updatedNamespaces"`
+	TotalNamespaces   int32 `json:"totalNamespaces"`
+	// LastBatchTime is when the last batch was updated
+	LastBatchTime *meta_v1.Time `json:"lastBatchTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ClusterCustomConfigList struct {
+	meta_v1.TypeMeta `json:",inline"`
+	meta_v1.ListMeta `json:"metadata"`
+
+	Items []ClusterCustomConfig `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CustomConfigOverride temporarily overrides the value of a CustomConfig of
+// its namespace, the value of the CustomConfig is restored once its TTL is
+// over or it is deleted
+type CustomConfigOverride struct {
+	meta_v1.TypeMeta   `json:",inline"`
+	meta_v1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CustomConfigOverrideSpec   `json:"spec"`
+	Status CustomConfigOverrideStatus `json:"status,omitempty"`
+}
+
+// CustomConfigOverrideSpec is the spec for a CustomConfigOverride resource
+type CustomConfigOverrideSpec struct {
+	// CustomConfigName is the name of the overridden CustomConfig
+	CustomConfigName string `json:"customConfigName"`
+	Value            string `json:"value"`
+	// TTL is how long the override lasts from its creation
+	TTL meta_v1.Duration `json:"ttl"`
+}
+
+// OverridePhase is the phase of a CustomConfigOverride
+type OverridePhase string
+
+const (
+	OverrideActive  OverridePhase = "Active"
+	OverrideExpired OverridePhase = "Expired"
+)
+
+// CustomConfigOverrideStatus is the status for a CustomConfigOverride resource
+type CustomConfigOverrideStatus struct {
+	Phase OverridePhase `json:"phase,omitempty"`
+	// ExpirationTime is when the TTL is over
+	ExpirationTime *meta_v1.Time `json:"expirationTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type CustomConfigOverrideList struct {
+	meta_v1.TypeMeta `json:",inline"`
+	meta_v1.ListMeta `json:"metadata"`
+
+	Items []CustomConfigOverride `json:"items"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CustomConfigDefaults holds the defaults of the fields left empty by the
+// CustomConfigs created in its namespace
+type CustomConfigDefaults struct {
+	meta_v1.TypeMeta   `json:",inline"`
+	meta_v1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CustomConfigDefaultsSpec `json:"spec"`
+}
+
+// CustomConfigDefaultsSpec is the spec for a CustomConfigDefaults resource
+type CustomConfigDefaultsSpec struct {
+	ConfigmapName   string         `json:"configmapName,omitempty"`
+	TargetNamespace string         `json:"targetNamespace,omitempty"`
+	DeletionPolicy  DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type CustomConfigDefaultsList struct {
+	meta_v1.TypeMeta `json:",inline"`
+	meta_v1.ListMeta `json:"metadata"`
+
+	Items []CustomConfigDefaults `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConfigSchema declares the keys allowed in a ConfigMap of its namespace,
+// which the CustomConfigs writing to the ConfigMap are validated against
+type ConfigSchema struct {
+	meta_v1.TypeMeta   `json:",inline"`
+	meta_v1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConfigSchemaSpec   `json:"spec"`
+	Status ConfigSchemaStatus `json:"status,omitempty"`
+}
+
+// ConfigSchemaSpec is the spec for a ConfigSchema resource
+type ConfigSchemaSpec struct {
+	// ConfigmapName is the ConfigMap of the namespace the schema applies to
+	ConfigmapName string `json:"configmapName"`
+	// Keys are the keys allowed in the ConfigMap, any other key is rejected
+	Keys []SchemaKey `json:"keys"`
+}
+
+// SchemaKey declares a key allowed in a ConfigMap
+type SchemaKey struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Type and Constraints, when set, restrict the values of the key like
+	// the ones of a CustomConfig
+	Type        ValueType         `json:"type,omitempty"`
+	Constraints *ValueConstraints `json:"constraints,omitempty"`
+	// Default, when set, is written to the key while no CustomConfig does
+	Default *string `json:"default,omitempty"`
+	// Required keys are reported until a CustomConfig provides them
+	Required bool `json:"required,omitempty"`
+}
+
+// ConfigSchemaStatus is the status for a ConfigSchema resource
+type ConfigSchemaStatus struct {
+	// MissingKeys lists the required keys no CustomConfig provides, which
+	// have no default
+	MissingKeys []string `json:"missingKeys,omitempty"`
+	// DefaultedKeys lists the keys set to their default
+	DefaultedKeys []string `json:"defaultedKeys,omitempty"`
+
+	Conditions []CustomConfigCondition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ConfigSchemaList struct {
+	meta_v1.TypeMeta `json:",inline"`
+	meta_v1.ListMeta `json:"metadata"`
+
+	Items []ConfigSchema `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConfigFreeze holds the changes to the CustomConfigs writing to the
+// namespaces matching its selector during its windows, the values last
+// applied stay written until the windows end
+type ConfigFreeze struct {
+	meta_v1.TypeMeta   `json:",inline"`
+	meta_v1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConfigFreezeSpec   `json:"spec"`
+	Status ConfigFreezeStatus `json:"status,omitempty"`
+}
+
+// ConfigFreezeSpec is the spec for a ConfigFreeze resource
+type ConfigFreezeSpec struct {
+	// NamespaceSelector selects the target namespaces frozen, all of them
+	// when not set
+	NamespaceSelector *meta_v1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Windows are the periods the changes are held during
+	Windows []FreezeWindow `json:"windows"`
+	// Reason tells why the changes are held, e.g. release 1.4
+	Reason string `json:"reason,omitempty"`
+}
+
+// FreezeWindow is either a one-off window from Start to End, or the
+// recurring windows of Schedule
+type FreezeWindow struct {
+	Start *meta_v1.Time `json:"start,omitempty"`
+	End   *meta_v1.Time `json:"end,omitempty"`
+	// Schedule opens recurring windows, e.g. every Friday from 16:00 for
+	// 64 hours
+	Schedule *ScheduleSpec `json:"schedule,omitempty"`
+}
+
+// ConfigFreezeStatus is the status for a ConfigFreeze resource
+type ConfigFreezeStatus struct {
+	// NextTransitionTime is when the freeze next starts or ends
+	NextTransitionTime *meta_v1.Time `json:"nextTransitionTime,omitempty"`
+
+	Conditions []CustomConfigCondition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ConfigFreezeList struct {
+	meta_v1.TypeMeta `json:",inline"`
+	meta_v1.ListMeta `json:"metadata"`
+
+	Items []ConfigFreeze `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CustomConfigPolicy protects namespaces, ConfigMaps and keys from the
+// CustomConfigs, on top of the policy the operator is started with
+type CustomConfigPolicy struct {
+	meta_v1.TypeMeta   `json:",inline"`
+	meta_v1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CustomConfigPolicySpec `json:"spec"`
+}
+
+// CustomConfigPolicySpec is the spec for a CustomConfigPolicy resource
+type CustomConfigPolicySpec struct {
+	// ProtectedNamespaces are the namespaces no CustomConfig may write to,
+	// as patterns, e.g. kube-*
+	ProtectedNamespaces []string `json:"protectedNamespaces,omitempty"`
+	// ProtectedConfigMaps are the patterns of the names of the ConfigMaps
+	// no CustomConfig may write to, e.g. kube-root-ca.crt, or of their
+	// namespace/name, e.g. prod-*/feature-flags
+	ProtectedConfigMaps []string `json:"protectedConfigMaps,omitempty"`
+	// ReservedKeyPrefixes are the prefixes of the keys no CustomConfig may
+	// write, e.g. kubernetes.io/
+	ReservedKeyPrefixes []string `json:"reservedKeyPrefixes,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type CustomConfigPolicyList struct {
+	meta_v1.TypeMeta `json:",inline"`
+	meta_v1.ListMeta `json:"metadata"`
+
+	Items []CustomConfigPolicy `json:"items"`
+}
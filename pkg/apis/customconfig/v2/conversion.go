@@ -0,0 +1,5 @@
+package v2
+
+// Hub marks v2 as the version every other version of CustomConfig converts
+// to and from
+func (*CustomConfig) Hub() {}
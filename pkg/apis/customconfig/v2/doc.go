@@ -0,0 +1,4 @@
+// +k8s:deepcopy-gen=package
+// Package v2 is the v2 version of the API.
+// +groupName=mtcil.com
+package v2
@@ -0,0 +1,384 @@
+package v2
+
+import (
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CustomConfig sets a key of a ConfigMap. v2 groups the fields of v1 by
+// concern: the ConfigMap written to, the history kept of its values and
+// when the key is set. Unlike v1, it can also label the ConfigMap written.
+type CustomConfig struct {
+	meta_v1.TypeMeta   `json:",inline"`
+	meta_v1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CustomConfigSpec   `json:"spec"`
+	Status CustomConfigStatus `json:"status,omitempty"`
+}
+
+// CustomConfigSpec is the spec for a CustomConfig resource
+type CustomConfigSpec struct {
+	// Target is the ConfigMap the key is set in
+	Target Target `json:"target"`
+
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	// ValueFrom, when set, takes the value from a source outside of the
+	// CustomConfig instead of Value
+	ValueFrom *ValueSource `json:"valueFrom,omitempty"`
+	// EncryptedValue, when set, is the value sealed with the public key of
+	// the operator, e.g. by crd-operator encrypt, which decrypts it and
+	// writes it to the Secret of Target instead of Value
+	EncryptedValue string `json:"encryptedValue,omitempty"`
+
+	// Type, when set, is the type Value must have, Value is normalized to
+	// the canonical form of its type before it is written
+	Type ValueType `json:"type,omitempty"`
+	// Constraints, when set, further restrict the values of a typed Value
+	Constraints *ValueConstraints `json:"constraints,omitempty"`
+
+	// File, when set, makes Key/Value an entry of a structured file stored
+	// under a single ConfigMap key instead of a ConfigMap key of its own
+	File *FileSpec `json:"file,omitempty"`
+
+	// Strategy selects how Value is written to Key, Replace by default
+	Strategy Strategy `json:"strategy,omitempty"`
+	// Priority orders the fragments merged into the same key, fragments
+	// with a higher priority are merged later and win conflicts
+	Priority int32 `json:"priority,omitempty"`
+
+	// RestartPolicy tells how the workloads consuming the ConfigMap are
+	// restarted when this CustomConfig changes it, the operator's default
+	// policy applies when not set
+	RestartPolicy RestartPolicy `json:"restartPolicy,omitempty"`
+	// Rollout, when set, watches the rollout of the Deployments consuming
+	// the ConfigMap after a change and reverts the change when they do not
+	// become healthy
+	Rollout *RolloutSpec `json:"rollout,omitempty"`
+
+	// History configures the applied values kept as ControllerRevisions
+	History *HistorySpec `json:"history,omitempty"`
+
+	// Activity, when set, limits when the key is set
+	Activity *ActivitySpec `json:"activity,omitempty"`
+
+	// Suspend stops writing the ConfigMap for this CustomConfig, which only
+	// reports in its status whether the ConfigMap drifted from it
+	Suspend bool `json:"suspend,omitempty"`
+	// DryRun stops writing the ConfigMap for this CustomConfig, the change
+	// it would make is recorded as a unified diff in its status and events
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// DeletionPolicy tells what happens to the key when this CustomConfig
+	// is deleted, Delete by default
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// Target is the ConfigMap a CustomConfig writes to
+type Target struct {
+	// Namespace is the namespace of the ConfigMap, the namespace of the
+	// operator when not set. Any other namespace than these two and the one
+	// of the CustomConfig is only written when the user who last changed the
+	// CustomConfig may write the ConfigMap there.
+	Namespace string `json:"namespace,omitempty"`
+	// ConfigMapName is the name of the ConfigMap
+	ConfigMapName string `json:"configMapName,omitempty"`
+	// SecretName is the Secret EncryptedValue is written to, the ConfigMap
+	// is not written then
+	SecretName string `json:"secretName,omitempty"`
+	// Immutable, when set, publishes the ConfigMap as immutable generations
+	// named <configMapName>-<contenthash> instead of updating it in place
+	Immutable *ImmutableSpec `json:"immutable,omitempty"`
+	// Labels are set on the ConfigMap or Secret written, in addition to the
+	// labels it already has. v1 has no such field and keeps them in its
+	// mtcil.com/target-labels annotation.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// HistorySpec configures the applied values kept as ControllerRevisions
+type HistorySpec struct {
+	// Limit is the number of applied values kept, 10 when not set
+	Limit *int32 `json:"limit,omitempty"`
+	// RollbackTo, when set, sets Value back to the one of that revision,
+	// after which it is cleared. A value taken from ValueFrom is pinned,
+	// ValueFrom is cleared. An encrypted value keeps no revisions and is not
+	// rolled back.
+	RollbackTo *int64 `json:"rollbackTo,omitempty"`
+}
+
+// ActivitySpec limits when the key is set
+type ActivitySpec struct {
+	// From, when set, is when the key is set, it is not before
+	From *meta_v1.Time `json:"from,omitempty"`
+	// Until, when set, is when the key is removed
+	Until *meta_v1.Time `json:"until,omitempty"`
+	// Schedule, when set, only sets the key during the windows it opens,
+	// within From and Until
+	Schedule *ScheduleSpec `json:"schedule,omitempty"`
+}
+
+// ValueSource is where the value of a CustomConfig comes from
+type ValueSource struct {
+	// External fetches the value from outside of Kubernetes
+	External *ExternalSource `json:"external,omitempty"`
+}
+
+// ExternalSource fetches a value from exactly one of a file, an HTTP(S) URL
+// or a Vault-compatible KV store
+type ExternalSource struct {
+	File  *FileSource  `json:"file,omitempty"`
+	HTTP  *HTTPSource  `json:"http,omitempty"`
+	Vault *VaultSource `json:"vault,omitempty"`
+	// PollInterval is how often the value is fetched again, 1m when not set
+	PollInterval *meta_v1.Duration `json:"pollInterval,omitempty"`
+}
+
+// FileSource reads the value from a file of the operator
+type FileSource struct {
+	// Path is the path of the file, e.g. of a mounted volume
+	Path string `json:"path"`
+}
+
+// HTTPSource fetches the value from an HTTP(S) URL
+type HTTPSource struct {
+	URL string `json:"url"`
+	// JSONPath, when set, extracts the value from the JSON response, e.g.
+	// {.flags.checkout.enabled}, else the whole response is the value
+	JSONPath string `json:"jsonPath,omitempty"`
+}
+
+// VaultSource reads the value from a key of a secret of a Vault-compatible
+// KV secrets engine
+type VaultSource struct {
+	// Address is the base URL of the server, e.g. https://vault:8200
+	Address string `json:"address"`
+	// Mount is where the KV engine is mounted, secret when empty
+	Mount string `json:"mount,omitempty"`
+	// Path is the path of the secret within the engine
+	Path string `json:"path"`
+	// Key is the key of the secret holding the value
+	Key string `json:"key"`
+	// Version is the version of the KV engine, 1 or 2, 2 when not set
+	Version int32 `json:"version,omitempty"`
+	// TokenSecretRef selects the key of a Secret of the namespace of the
+	// CustomConfig holding the token of the requests
+	TokenSecretRef *core_v1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+}
+
+// ValueType is the type of the value of a CustomConfig
+type ValueType string
+
+const (
+	ValueString   ValueType = "string"
+	ValueInt      ValueType = "int"
+	ValueBool     ValueType = "bool"
+	ValueDuration ValueType = "duration"
+	ValueJSON     ValueType = "json"
+	ValueYAML     ValueType = "yaml"
+)
+
+// ValueConstraints restrict the values of a typed CustomConfig
+type ValueConstraints struct {
+	// Minimum and Maximum bound an int or duration value, e.g. 30s, or the
+	// length of a string value
+	Minimum string `json:"minimum,omitempty"`
+	Maximum string `json:"maximum,omitempty"`
+	// Enum lists the values allowed, compared once normalized
+	Enum []string `json:"enum,omitempty"`
+	// Pattern is a regular expression the normalized value must match
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// DeletionPolicy is what happens to the key of a deleted CustomConfig
+type DeletionPolicy string
+
+const (
+	// DeletionDelete removes the key, and deletes the ConfigMap once it is
+	// empty provided every key it had was written by the operator
+	DeletionDelete DeletionPolicy = "Delete"
+	// DeletionRetain leaves the key and the ConfigMap as they are
+	DeletionRetain DeletionPolicy = "Retain"
+	// DeletionRetainConfigMap removes the key but never deletes the ConfigMap
+	DeletionRetainConfigMap DeletionPolicy = "RetainConfigMap"
+)
+
+// ScheduleSpec opens recurring windows during which the key is set
+type ScheduleSpec struct {
+	// Cron is the standard cron expression of the start of the windows,
+	// e.g. "0 2 * * *", which may be prefixed with CRON_TZ=<zone>
+	Cron string `json:"cron"`
+	// Duration is how long each window lasts
+	Duration meta_v1.Duration `json:"duration"`
+}
+
+// RolloutSpec configures the health-gated rollout of a change
+type RolloutSpec struct {
+	// Window is how long the consumers have to become healthy, 5m when not set
+	Window *meta_v1.Duration `json:"window,omitempty"`
+}
+
+// RestartPolicy is the way the workloads consuming a ConfigMap are restarted
+type RestartPolicy string
+
+const (
+	// RestartImmediate rolls the consumers as soon as the ConfigMap changes
+	RestartImmediate RestartPolicy = "Immediate"
+	// RestartDebounced rolls the consumers once the ConfigMap has stopped
+	// changing for a while, so that a burst of changes rolls them once
+	RestartDebounced RestartPolicy = "Debounced"
+	// RestartManual never rolls the consumers, opting out of restarts
+	RestartManual RestartPolicy = "Manual"
+)
+
+// ImmutableSpec selects the workloads to point at the latest generation of
+// the ConfigMap and how many older generations to keep
+type ImmutableSpec struct {
+	// Selector selects the Deployments and StatefulSets whose references to
+	// the ConfigMap are rewritten, all of them when not set
+	Selector *meta_v1.LabelSelector `json:"selector,omitempty"`
+	// Retain is the number of older generations kept, 3 when not set
+	Retain *int32 `json:"retain,omitempty"`
+}
+
+// Strategy is the way a CustomConfig writes its value to the ConfigMap key
+type Strategy string
+
+const (
+	// StrategyReplace sets the key to the value
+	StrategyReplace Strategy = "Replace"
+	// StrategyMerge deep-merges the value, a JSON or YAML fragment, with
+	// the fragments of every other CustomConfig merging into the key
+	StrategyMerge Strategy = "Merge"
+)
+
+// FileFormat is the format a file key of the ConfigMap is rendered in
+type FileFormat string
+
+const (
+	FormatProperties FileFormat = "properties"
+	FormatDotenv     FileFormat = "dotenv"
+	FormatJSON       FileFormat = "json"
+	FormatYAML       FileFormat = "yaml"
+	FormatTOML       FileFormat = "toml"
+	FormatINI        FileFormat = "ini"
+)
+
+// FileSpec names the ConfigMap key holding the rendered file and its format
+type FileSpec struct {
+	// Name is the ConfigMap key, e.g. application.properties
+	Name string `json:"name"`
+	// Format defaults to the one implied by the extension of Name
+	Format FileFormat `json:"format,omitempty"`
+}
+
+// CustomConfigStatus is the status for a CustomConfig resource
+type CustomConfigStatus struct {
+	// AppliedValue is the value last written to the ConfigMap
+	AppliedValue string `json:"appliedValue,omitempty"`
+	// AppliedGeneration is the generation AppliedValue comes from
+	AppliedGeneration int64 `json:"appliedGeneration,omitempty"`
+	// RolledBackGeneration is the last generation whose rollout failed and
+	// was reverted, it is not applied again
+	RolledBackGeneration int64 `json:"rolledBackGeneration,omitempty"`
+	// ApprovedGeneration is the last generation approved in a namespace
+	// requiring approval, the value of a later one is not applied until it
+	// is approved
+	ApprovedGeneration int64 `json:"approvedGeneration,omitempty"`
+	// ApprovedBy is the user who approved ApprovedGeneration
+	ApprovedBy string `json:"approvedBy,omitempty"`
+
+	// CurrentRevision is the revision of AppliedValue
+	CurrentRevision int64 `json:"currentRevision,omitempty"`
+	// Revisions lists the revisions kept, oldest first
+	Revisions []Revision `json:"revisions,omitempty"`
+
+	// Override is the name of the CustomConfigOverride whose value is
+	// currently written instead of Value
+	Override string `json:"override,omitempty"`
+
+	// DryRunDiff is the unified diff of the change to the ConfigMap a dry
+	// run would make, empty when it would change nothing
+	DryRunDiff string `json:"dryRunDiff,omitempty"`
+
+	// NextTransitionTime is when the key is next set or removed following
+	// the activity of the CustomConfig
+	NextTransitionTime *meta_v1.Time `json:"nextTransitionTime,omitempty"`
+
+	Conditions []CustomConfigCondition `json:"conditions,omitempty"`
+}
+
+// Revision is an applied value kept as a ControllerRevision
+type Revision struct {
+	Revision int64 `json:"revision"`
+	// Name is the name of the ControllerRevision holding the value
+	Name              string       `json:"name"`
+	CreationTimestamp meta_v1.Time `json:"creationTimestamp,omitempty"`
+}
+
+// ConditionType is the type of a CustomConfigCondition
+type ConditionType string
+
+const (
+	// ConditionRolledBack is true when the rollout of the current generation
+	// failed and the previous value was restored
+	ConditionRolledBack ConditionType = "RolledBack"
+	// ConditionActive is true when the key is set, i.e. when the time is
+	// within the activity of the CustomConfig
+	ConditionActive ConditionType = "Active"
+	// ConditionSuspended is true when the ConfigMap is not written, because
+	// of spec.suspend or because the operator is paused
+	ConditionSuspended ConditionType = "Suspended"
+	// ConditionDrifted is true when the ConfigMap of a suspended
+	// CustomConfig differs from what it would write
+	ConditionDrifted ConditionType = "Drifted"
+	// ConditionVerified is false when the namespace of the CustomConfig
+	// requires it to be signed and it has no valid signature, it is not
+	// applied then
+	ConditionVerified ConditionType = "Verified"
+	// ConditionApproved is false when the namespace of the CustomConfig
+	// requires changes to be approved and its generation is not, the value
+	// last approved stays applied then
+	ConditionApproved ConditionType = "Approved"
+	// ConditionAuthorized is false when the user who last changed the
+	// CustomConfig could not write its ConfigMap or Secret directly, it is
+	// not applied then
+	ConditionAuthorized ConditionType = "Authorized"
+	// ConditionPolicyViolation is true when the CustomConfig writes to a
+	// namespace, ConfigMap or key the policy of the operator protects, it
+	// is not applied then
+	ConditionPolicyViolation ConditionType = "PolicyViolation"
+	// ConditionFrozen is true when a ConfigFreeze holds the changes to the
+	// CustomConfig, false when its emergency override annotation lets them
+	// through
+	ConditionFrozen ConditionType = "Frozen"
+	// ConditionDecrypted is false when EncryptedValue cannot be decrypted
+	// by the operator
+	ConditionDecrypted ConditionType = "Decrypted"
+	// ConditionValueFetched is false when the value of spec.valueFrom could
+	// not be fetched, the last value fetched is written meanwhile
+	ConditionValueFetched ConditionType = "ValueFetched"
+	// ConditionValueValid is false when the value does not have the type
+	// of the CustomConfig or breaks its constraints, it is not written then
+	ConditionValueValid ConditionType = "ValueValid"
+)
+
+// CustomConfigCondition describes the state of a CustomConfig at a point in time
+type CustomConfigCondition struct {
+	Type               ConditionType           `json:"type"`
+	Status             core_v1.ConditionStatus `json:"status"`
+	LastTransitionTime meta_v1.Time            `json:"lastTransitionTime,omitempty"`
+	Reason             string                  `json:"reason,omitempty"`
+	Message            string                  `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type CustomConfigList struct {
+	meta_v1.TypeMeta `json:",inline"`
+	meta_v1.ListMeta `json:"metadata"`
+
+	Items []CustomConfig `json:"items"`
+}
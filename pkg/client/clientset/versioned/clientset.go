@@ -22,6 +22,7 @@ import (
 	"fmt"
 
 	mtcilv1 "github.com/onkarbanerjee/crd-operator/pkg/client/clientset/versioned/typed/customconfig/v1"
+	mtcilv2 "github.com/onkarbanerjee/crd-operator/pkg/client/clientset/versioned/typed/customconfig/v2"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
@@ -30,6 +31,7 @@ import (
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	MtcilV1() mtcilv1.MtcilV1Interface
+	MtcilV2() mtcilv2.MtcilV2Interface
 }
 
 // Clientset contains the clients for groups. Each group has exactly one
@@ -37,6 +39,7 @@ type Interface interface {
 type Clientset struct {
 	*discovery.DiscoveryClient
 	mtcilV1 *mtcilv1.MtcilV1Client
+	mtcilV2 *mtcilv2.MtcilV2Client
 }
 
 // MtcilV1 retrieves the MtcilV1Client
@@ -44,6 +47,11 @@ func (c *Clientset) MtcilV1() mtcilv1.MtcilV1Interface {
 	return c.mtcilV1
 }
 
+// MtcilV2 retrieves the MtcilV2Client
+func (c *Clientset) MtcilV2() mtcilv2.MtcilV2Interface {
+	return c.mtcilV2
+}
+
 // Discovery retrieves the DiscoveryClient
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	if c == nil {
@@ -69,6 +77,10 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	cs.mtcilV2, err = mtcilv2.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfig(&configShallowCopy)
 	if err != nil {
@@ -82,6 +94,7 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 func NewForConfigOrDie(c *rest.Config) *Clientset {
 	var cs Clientset
 	cs.mtcilV1 = mtcilv1.NewForConfigOrDie(c)
+	cs.mtcilV2 = mtcilv2.NewForConfigOrDie(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClientForConfigOrDie(c)
 	return &cs
@@ -91,6 +104,7 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.mtcilV1 = mtcilv1.New(c)
+	cs.mtcilV2 = mtcilv2.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
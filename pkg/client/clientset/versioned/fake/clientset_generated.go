@@ -22,6 +22,8 @@ import (
 	clientset "github.com/onkarbanerjee/crd-operator/pkg/client/clientset/versioned"
 	mtcilv1 "github.com/onkarbanerjee/crd-operator/pkg/client/clientset/versioned/typed/customconfig/v1"
 	fakemtcilv1 "github.com/onkarbanerjee/crd-operator/pkg/client/clientset/versioned/typed/customconfig/v1/fake"
+	mtcilv2 "github.com/onkarbanerjee/crd-operator/pkg/client/clientset/versioned/typed/customconfig/v2"
+	fakemtcilv2 "github.com/onkarbanerjee/crd-operator/pkg/client/clientset/versioned/typed/customconfig/v2/fake"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
@@ -80,3 +82,8 @@ var _ clientset.Interface = &Clientset{}
 func (c *Clientset) MtcilV1() mtcilv1.MtcilV1Interface {
 	return &fakemtcilv1.FakeMtcilV1{Fake: &c.Fake}
 }
+
+// MtcilV2 retrieves the MtcilV2Client
+func (c *Clientset) MtcilV2() mtcilv2.MtcilV2Interface {
+	return &fakemtcilv2.FakeMtcilV2{Fake: &c.Fake}
+}
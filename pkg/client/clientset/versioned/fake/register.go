@@ -20,6 +20,7 @@ package fake
 
 import (
 	mtcilv1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	mtcilv2 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v2"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
@@ -32,6 +33,7 @@ var codecs = serializer.NewCodecFactory(scheme)
 
 var localSchemeBuilder = runtime.SchemeBuilder{
 	mtcilv1.AddToScheme,
+	mtcilv2.AddToScheme,
 }
 
 // AddToScheme adds all types of this clientset into the given scheme. This allows composition
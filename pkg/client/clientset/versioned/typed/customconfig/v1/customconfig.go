@@ -40,6 +40,7 @@ type CustomConfigsGetter interface {
 type CustomConfigInterface interface {
 	Create(ctx context.Context, customConfig *v1.CustomConfig, opts metav1.CreateOptions) (*v1.CustomConfig, error)
 	Update(ctx context.Context, customConfig *v1.CustomConfig, opts metav1.UpdateOptions) (*v1.CustomConfig, error)
+	UpdateStatus(ctx context.Context, customConfig *v1.CustomConfig, opts metav1.UpdateOptions) (*v1.CustomConfig, error)
 	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
 	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.CustomConfig, error)
@@ -135,6 +136,22 @@ func (c *customConfigs) Update(ctx context.Context, customConfig *v1.CustomConfi
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *customConfigs) UpdateStatus(ctx context.Context, customConfig *v1.CustomConfig, opts metav1.UpdateOptions) (result *v1.CustomConfig, err error) {
+	result = &v1.CustomConfig{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("customconfigs").
+		Name(customConfig.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(customConfig).
+		Do().
+		Into(result)
+	return
+}
+
 // Delete takes name of the customConfig and deletes it. Returns an error if one occurs.
 func (c *customConfigs) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
 	return c.client.Delete().
@@ -26,6 +26,12 @@ import (
 
 type MtcilV1Interface interface {
 	RESTClient() rest.Interface
+	ClusterCustomConfigsGetter
+	ConfigFreezesGetter
+	ConfigSchemasGetter
+	CustomConfigDefaultsGetter
+	CustomConfigOverridesGetter
+	CustomConfigPolicysGetter
 	CustomConfigsGetter
 }
 
@@ -34,6 +40,30 @@ type MtcilV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *MtcilV1Client) ClusterCustomConfigs() ClusterCustomConfigInterface {
+	return newClusterCustomConfigs(c)
+}
+
+func (c *MtcilV1Client) ConfigFreezes() ConfigFreezeInterface {
+	return newConfigFreezes(c)
+}
+
+func (c *MtcilV1Client) ConfigSchemas(namespace string) ConfigSchemaInterface {
+	return newConfigSchemas(c, namespace)
+}
+
+func (c *MtcilV1Client) CustomConfigDefaults(namespace string) CustomConfigDefaultsInterface {
+	return newCustomConfigDefaults(c, namespace)
+}
+
+func (c *MtcilV1Client) CustomConfigOverrides(namespace string) CustomConfigOverrideInterface {
+	return newCustomConfigOverrides(c, namespace)
+}
+
+func (c *MtcilV1Client) CustomConfigPolicys() CustomConfigPolicyInterface {
+	return newCustomConfigPolicys(c)
+}
+
 func (c *MtcilV1Client) CustomConfigs(namespace string) CustomConfigInterface {
 	return newCustomConfigs(c, namespace)
 }
@@ -102,6 +102,18 @@ func (c *FakeCustomConfigs) Update(ctx context.Context, customConfig *customconf
 	return obj.(*customconfigv1.CustomConfig), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeCustomConfigs) UpdateStatus(ctx context.Context, customConfig *customconfigv1.CustomConfig, opts v1.UpdateOptions) (*customconfigv1.CustomConfig, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(customconfigsResource, "status", c.ns, customConfig), &customconfigv1.CustomConfig{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*customconfigv1.CustomConfig), err
+}
+
 // Delete takes name of the customConfig and deletes it. Returns an error if one occurs.
 func (c *FakeCustomConfigs) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
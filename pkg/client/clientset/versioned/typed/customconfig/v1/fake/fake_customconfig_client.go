@@ -28,6 +28,30 @@ type FakeMtcilV1 struct {
 	*testing.Fake
 }
 
+func (c *FakeMtcilV1) ClusterCustomConfigs() v1.ClusterCustomConfigInterface {
+	return &FakeClusterCustomConfigs{c}
+}
+
+func (c *FakeMtcilV1) ConfigFreezes() v1.ConfigFreezeInterface {
+	return &FakeConfigFreezes{c}
+}
+
+func (c *FakeMtcilV1) ConfigSchemas(namespace string) v1.ConfigSchemaInterface {
+	return &FakeConfigSchemas{c, namespace}
+}
+
+func (c *FakeMtcilV1) CustomConfigDefaults(namespace string) v1.CustomConfigDefaultsInterface {
+	return &FakeCustomConfigDefaults{c, namespace}
+}
+
+func (c *FakeMtcilV1) CustomConfigOverrides(namespace string) v1.CustomConfigOverrideInterface {
+	return &FakeCustomConfigOverrides{c, namespace}
+}
+
+func (c *FakeMtcilV1) CustomConfigPolicys() v1.CustomConfigPolicyInterface {
+	return &FakeCustomConfigPolicys{c}
+}
+
 func (c *FakeMtcilV1) CustomConfigs(namespace string) v1.CustomConfigInterface {
 	return &FakeCustomConfigs{c, namespace}
 }
@@ -18,4 +18,16 @@ limitations under the License.
 
 package v1
 
+type ClusterCustomConfigExpansion interface{}
+
+type ConfigFreezeExpansion interface{}
+
+type ConfigSchemaExpansion interface{}
+
+type CustomConfigDefaultsExpansion interface{}
+
 type CustomConfigExpansion interface{}
+
+type CustomConfigOverrideExpansion interface{}
+
+type CustomConfigPolicyExpansion interface{}
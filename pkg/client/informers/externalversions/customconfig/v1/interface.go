@@ -24,6 +24,18 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// ClusterCustomConfigs returns a ClusterCustomConfigInformer.
+	ClusterCustomConfigs() ClusterCustomConfigInformer
+	// ConfigFreezes returns a ConfigFreezeInformer.
+	ConfigFreezes() ConfigFreezeInformer
+	// ConfigSchemas returns a ConfigSchemaInformer.
+	ConfigSchemas() ConfigSchemaInformer
+	// CustomConfigDefaults returns a CustomConfigDefaultsInformer.
+	CustomConfigDefaults() CustomConfigDefaultsInformer
+	// CustomConfigOverrides returns a CustomConfigOverrideInformer.
+	CustomConfigOverrides() CustomConfigOverrideInformer
+	// CustomConfigPolicys returns a CustomConfigPolicyInformer.
+	CustomConfigPolicys() CustomConfigPolicyInformer
 	// CustomConfigs returns a CustomConfigInformer.
 	CustomConfigs() CustomConfigInformer
 }
@@ -39,6 +51,36 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// ClusterCustomConfigs returns a ClusterCustomConfigInformer.
+func (v *version) ClusterCustomConfigs() ClusterCustomConfigInformer {
+	return &clusterCustomConfigInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ConfigFreezes returns a ConfigFreezeInformer.
+func (v *version) ConfigFreezes() ConfigFreezeInformer {
+	return &configFreezeInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ConfigSchemas returns a ConfigSchemaInformer.
+func (v *version) ConfigSchemas() ConfigSchemaInformer {
+	return &configSchemaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// CustomConfigDefaults returns a CustomConfigDefaultsInformer.
+func (v *version) CustomConfigDefaults() CustomConfigDefaultsInformer {
+	return &customConfigDefaultsInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// CustomConfigOverrides returns a CustomConfigOverrideInformer.
+func (v *version) CustomConfigOverrides() CustomConfigOverrideInformer {
+	return &customConfigOverrideInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// CustomConfigPolicys returns a CustomConfigPolicyInformer.
+func (v *version) CustomConfigPolicys() CustomConfigPolicyInformer {
+	return &customConfigPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // CustomConfigs returns a CustomConfigInformer.
 func (v *version) CustomConfigs() CustomConfigInformer {
 	return &customConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
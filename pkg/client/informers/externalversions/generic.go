@@ -22,6 +22,7 @@ import (
 	"fmt"
 
 	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	v2 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v2"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 )
@@ -53,9 +54,25 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=mtcil.com, Version=v1
+	case v1.SchemeGroupVersion.WithResource("clustercustomconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Mtcil().V1().ClusterCustomConfigs().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("configfreezes"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Mtcil().V1().ConfigFreezes().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("configschemas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Mtcil().V1().ConfigSchemas().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("customconfigdefaults"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Mtcil().V1().CustomConfigDefaults().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("customconfigoverrides"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Mtcil().V1().CustomConfigOverrides().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("customconfigpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Mtcil().V1().CustomConfigPolicys().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("customconfigs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Mtcil().V1().CustomConfigs().Informer()}, nil
 
+	// Group=mtcil.com, Version=v2
+	case v2.SchemeGroupVersion.WithResource("customconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Mtcil().V2().CustomConfigs().Informer()}, nil
+
 	}
 
 	return nil, fmt.Errorf("no informer found for %v", resource)
@@ -18,6 +18,30 @@ limitations under the License.
 
 package v1
 
+// ClusterCustomConfigListerExpansion allows custom methods to be added to
+// ClusterCustomConfigLister.
+type ClusterCustomConfigListerExpansion interface{}
+
+// ConfigFreezeListerExpansion allows custom methods to be added to
+// ConfigFreezeLister.
+type ConfigFreezeListerExpansion interface{}
+
+// ConfigSchemaListerExpansion allows custom methods to be added to
+// ConfigSchemaLister.
+type ConfigSchemaListerExpansion interface{}
+
+// ConfigSchemaNamespaceListerExpansion allows custom methods to be added to
+// ConfigSchemaNamespaceLister.
+type ConfigSchemaNamespaceListerExpansion interface{}
+
+// CustomConfigDefaultsListerExpansion allows custom methods to be added to
+// CustomConfigDefaultsLister.
+type CustomConfigDefaultsListerExpansion interface{}
+
+// CustomConfigDefaultsNamespaceListerExpansion allows custom methods to be added to
+// CustomConfigDefaultsNamespaceLister.
+type CustomConfigDefaultsNamespaceListerExpansion interface{}
+
 // CustomConfigListerExpansion allows custom methods to be added to
 // CustomConfigLister.
 type CustomConfigListerExpansion interface{}
@@ -25,3 +49,15 @@ type CustomConfigListerExpansion interface{}
 // CustomConfigNamespaceListerExpansion allows custom methods to be added to
 // CustomConfigNamespaceLister.
 type CustomConfigNamespaceListerExpansion interface{}
+
+// CustomConfigOverrideListerExpansion allows custom methods to be added to
+// CustomConfigOverrideLister.
+type CustomConfigOverrideListerExpansion interface{}
+
+// CustomConfigOverrideNamespaceListerExpansion allows custom methods to be added to
+// CustomConfigOverrideNamespaceLister.
+type CustomConfigOverrideNamespaceListerExpansion interface{}
+
+// CustomConfigPolicyListerExpansion allows custom methods to be added to
+// CustomConfigPolicyLister.
+type CustomConfigPolicyListerExpansion interface{}
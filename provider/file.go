@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+)
+
+// File reads the value from a file of the operator, e.g. a mounted volume
+type File struct {
+	Path string
+}
+
+// Value returns the content of the file, without its trailing newline
+func (f *File) Value(ctx context.Context) (string, error) {
+	data, err := ioutil.ReadFile(f.Path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
+
+func (f *File) String() string {
+	return "file " + f.Path
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// maxBodySize bounds the responses read from the HTTP sources
+const maxBodySize = 1024 * 1024
+
+// HTTP fetches the value from an HTTP(S) URL
+type HTTP struct {
+	URL string
+	// JSONPath, when set, extracts the value from the JSON response, e.g.
+	// {.flags.checkout.enabled}, else the whole response is the value
+	JSONPath string
+	// Client sends the requests, a client with a 10s timeout when nil
+	Client *http.Client
+}
+
+// Value fetches the URL and returns the value extracted from the response
+func (h *HTTP) Value(ctx context.Context) (string, error) {
+	body, err := get(ctx, client(h.Client), h.URL, nil)
+	if err != nil {
+		return "", err
+	}
+	if h.JSONPath == "" {
+		return strings.TrimSuffix(string(body), "\n"), nil
+	}
+	var data interface{}
+	if err = json.Unmarshal(body, &data); err != nil {
+		return "", fmt.Errorf("response of %s is not json: %v", h.URL, err)
+	}
+	return extract(h.JSONPath, data)
+}
+
+func (h *HTTP) String() string {
+	if h.JSONPath == "" {
+		return "url " + h.URL
+	}
+	return "url " + h.URL + " at " + h.JSONPath
+}
+
+// get sends a GET request to url and returns the body of a 2xx response
+func get(ctx context.Context, c *http.Client, url string, header http.Header) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(&limitedReader{r: resp.Body, n: maxBodySize})
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return body, nil
+}
+
+// extract returns the result of the JSONPath expression path on data. A
+// single string is returned as is, any other result as JSON.
+func extract(path string, data interface{}) (string, error) {
+	if !strings.HasPrefix(path, "{") {
+		path = "{" + path + "}"
+	}
+	j := jsonpath.New("value")
+	if err := j.Parse(path); err != nil {
+		return "", fmt.Errorf("invalid jsonpath %s: %v", path, err)
+	}
+	results, err := j.FindResults(data)
+	if err != nil {
+		return "", err
+	}
+	if len(results) != 1 || len(results[0]) != 1 {
+		return "", fmt.Errorf("jsonpath %s does not select a single value", path)
+	}
+	v := results[0][0].Interface()
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err = encoder.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// limitedReader fails once more than n bytes are read, rather than cutting
+// the value short as io.LimitReader would
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return n, fmt.Errorf("response is larger than %d bytes", maxBodySize)
+	}
+	return n, err
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		jsonPath string
+		want     string
+		wantErr  string
+	}{
+		{name: "whole body", status: http.StatusOK, body: "on\n", want: "on"},
+		{name: "string", status: http.StatusOK, body: `{"flags":{"checkout":{"mode":"fast"}}}`, jsonPath: "{.flags.checkout.mode}", want: "fast"},
+		{name: "without braces", status: http.StatusOK, body: `{"flags":{"checkout":{"mode":"fast"}}}`, jsonPath: ".flags.checkout.mode", want: "fast"},
+		{name: "bool", status: http.StatusOK, body: `{"enabled":true}`, jsonPath: "{.enabled}", want: "true"},
+		{name: "object", status: http.StatusOK, body: `{"limits":{"cpu":"<1>"}}`, jsonPath: "{.limits}", want: `{"cpu":"<1>"}`},
+		{name: "several values", status: http.StatusOK, body: `{"a":[1,2]}`, jsonPath: "{.a[*]}", wantErr: "does not select a single value"},
+		{name: "missing", status: http.StatusOK, body: `{"a":1}`, jsonPath: "{.b}", wantErr: "b is not found"},
+		{name: "invalid jsonpath", status: http.StatusOK, body: `{"a":1}`, jsonPath: "{.a[}", wantErr: "invalid jsonpath"},
+		{name: "not json", status: http.StatusOK, body: "on", jsonPath: "{.a}", wantErr: "is not json"},
+		{name: "not found", status: http.StatusNotFound, body: "{}", wantErr: "404 Not Found"},
+		{name: "server error", status: http.StatusInternalServerError, body: "on", wantErr: "500 Internal Server Error"},
+		{name: "oversized", status: http.StatusOK, body: strings.Repeat("a", maxBodySize+1), wantErr: fmt.Sprintf("larger than %d bytes", maxBodySize)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			h := &HTTP{URL: server.URL, JSONPath: tt.jsonPath, Client: server.Client()}
+			got, err := h.Value(context.Background())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimitedReader(t *testing.T) {
+	got, err := ioutil.ReadAll(&limitedReader{r: strings.NewReader("abc"), n: 3})
+	if err != nil || string(got) != "abc" {
+		t.Errorf("reading up to the limit: got %q, %v", got, err)
+	}
+	if _, err = ioutil.ReadAll(&limitedReader{r: strings.NewReader("abcd"), n: 3}); err == nil {
+		t.Error("reading beyond the limit did not fail")
+	}
+}
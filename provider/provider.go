@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ValueProvider fetches a value from outside of Kubernetes
+type ValueProvider interface {
+	// Value returns the current value
+	Value(ctx context.Context) (string, error)
+	// String describes where the value comes from, for the logs and the
+	// conditions, it never holds credentials
+	String() string
+}
+
+// defaultClient is used by the HTTP providers which are not given a client
+var defaultClient = &http.Client{Timeout: 10 * time.Second}
+
+// client returns c, or the default client when it is nil
+func client(c *http.Client) *http.Client {
+	if c != nil {
+		return c
+	}
+	return defaultClient
+}
+
+// Cache keeps the values fetched by the providers, so that a value is only
+// fetched again once its poll interval is over
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+// entry is a cached value
+type entry struct {
+	// source tells which provider the value comes from, a value of a
+	// previous source is fetched again
+	source    string
+	value     string
+	fetchedAt time.Time
+	ok        bool
+}
+
+// Get returns the value of p cached under key, fetching it when it is older
+// than interval or comes from another source, and tells whether it fetched
+// it. A failed fetch returns the error along with the last value fetched
+// from the same source, if any.
+func (c *Cache) Get(ctx context.Context, key string, p ValueProvider, interval time.Duration) (string, bool, error) {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	c.mu.Unlock()
+	source := p.String()
+	if ok && e.source == source && time.Since(e.fetchedAt) < interval {
+		return e.value, false, nil
+	}
+
+	value, err := p.Value(ctx)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = map[string]entry{}
+	}
+	if err != nil {
+		if ok && e.source == source {
+			// the stale value is kept, and the source tried again at the
+			// next interval
+			e.fetchedAt = time.Now()
+			c.entries[key] = e
+			return e.value, true, err
+		}
+		c.entries[key] = entry{source: source, fetchedAt: time.Now()}
+		return "", true, err
+	}
+	c.entries[key] = entry{source: source, value: value, fetchedAt: time.Now(), ok: true}
+	return value, true, nil
+}
+
+// Peek returns the value cached under key, without fetching it
+func (c *Cache) Peek(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	return e.value, ok && e.ok
+}
+
+// Forget drops the value cached under key
+func (c *Cache) Forget(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
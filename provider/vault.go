@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Vault reads the value from a key of a secret of a Vault-compatible KV
+// secrets engine
+type Vault struct {
+	// Address is the base URL of the server, e.g. https://vault:8200
+	Address string
+	// Mount is where the KV engine is mounted, secret when empty
+	Mount string
+	// Path is the path of the secret within the engine
+	Path string
+	// Key is the key of the secret holding the value
+	Key string
+	// Version is the version of the KV engine, 1 or 2, 2 when not set
+	Version int
+	// Token returns the token authenticating the requests, which are not
+	// authenticated when it is nil
+	Token func() (string, error)
+	// Client sends the requests, a client with a 10s timeout when nil
+	Client *http.Client
+}
+
+// Value reads the secret and returns its key
+func (v *Vault) Value(ctx context.Context) (string, error) {
+	header := http.Header{}
+	if v.Token != nil {
+		token, err := v.Token()
+		if err != nil {
+			return "", fmt.Errorf("reading vault token: %v", err)
+		}
+		header.Set("X-Vault-Token", token)
+	}
+	body, err := get(ctx, client(v.Client), v.url(), header)
+	if err != nil {
+		return "", err
+	}
+
+	var secret struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err = json.Unmarshal(body, &secret); err != nil {
+		return "", fmt.Errorf("invalid response: %v", err)
+	}
+	data := secret.Data
+	if v.Version != 1 {
+		// the data of a KV v2 secret is nested with its metadata
+		var versioned struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err = json.Unmarshal(data, &versioned); err != nil {
+			return "", fmt.Errorf("invalid response: %v", err)
+		}
+		data = versioned.Data
+	}
+	// e.g. a KV v1 secret read as a v2 one
+	if len(data) == 0 {
+		return "", fmt.Errorf("%s has no data", v)
+	}
+
+	var keys map[string]interface{}
+	if err = json.Unmarshal(data, &keys); err != nil {
+		return "", fmt.Errorf("invalid response: %v", err)
+	}
+	value, ok := keys[v.Key]
+	if !ok {
+		return "", fmt.Errorf("%s has no key %s", v, v.Key)
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	encoded, err := json.Marshal(value)
+	return string(encoded), err
+}
+
+// url returns the URL of the secret
+func (v *Vault) url() string {
+	mount := v.Mount
+	if mount == "" {
+		mount = "secret"
+	}
+	parts := []string{strings.TrimSuffix(v.Address, "/"), "v1", strings.Trim(mount, "/")}
+	if v.Version != 1 {
+		parts = append(parts, "data")
+	}
+	return strings.Join(append(parts, strings.Trim(v.Path, "/")), "/")
+}
+
+func (v *Vault) String() string {
+	return "vault " + v.url() + " key " + v.Key
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVaultValue(t *testing.T) {
+	const (
+		v1Secret = `{"data":{"password":"s3cr3t","port":5432}}`
+		v2Secret = `{"data":{"data":{"password":"s3cr3t","port":5432},"metadata":{"version":3}}}`
+	)
+	tests := []struct {
+		name     string
+		vault    Vault
+		path     string
+		body     string
+		status   int
+		want     string
+		wantErr  string
+		wantAuth string
+	}{
+		{name: "kv v2", vault: Vault{Path: "app/db", Key: "password"}, path: "/v1/secret/data/app/db", body: v2Secret, want: "s3cr3t"},
+		{name: "kv v2 mount", vault: Vault{Mount: "/kv/", Path: "/app/db", Key: "password", Version: 2}, path: "/v1/kv/data/app/db", body: v2Secret, want: "s3cr3t"},
+		{name: "kv v1", vault: Vault{Path: "app/db", Key: "password", Version: 1}, path: "/v1/secret/app/db", body: v1Secret, want: "s3cr3t"},
+		{name: "not a string", vault: Vault{Path: "app/db", Key: "port"}, path: "/v1/secret/data/app/db", body: v2Secret, want: "5432"},
+		{name: "token", vault: Vault{Path: "app/db", Key: "password", Token: func() (string, error) { return "t0ken", nil }}, path: "/v1/secret/data/app/db", body: v2Secret, want: "s3cr3t", wantAuth: "t0ken"},
+		{name: "token error", vault: Vault{Path: "app/db", Key: "password", Token: func() (string, error) { return "", errors.New("no secret") }}, wantErr: "reading vault token: no secret"},
+		{name: "missing key", vault: Vault{Path: "app/db", Key: "user"}, path: "/v1/secret/data/app/db", body: v2Secret, wantErr: "has no key user"},
+		{name: "v1 shape read as v2", vault: Vault{Path: "app/db", Key: "password"}, path: "/v1/secret/data/app/db", body: v1Secret, wantErr: "has no data"},
+		{name: "forbidden", vault: Vault{Path: "app/db", Key: "password"}, path: "/v1/secret/data/app/db", status: http.StatusForbidden, body: `{"errors":["permission denied"]}`, wantErr: "403 Forbidden"},
+		{name: "invalid response", vault: Vault{Path: "app/db", Key: "password"}, path: "/v1/secret/data/app/db", body: "<html>", wantErr: "invalid response"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.path {
+					http.NotFound(w, r)
+					return
+				}
+				if got := r.Header.Get("X-Vault-Token"); got != tt.wantAuth {
+					t.Errorf("got token %q, want %q", got, tt.wantAuth)
+				}
+				status := tt.status
+				if status == 0 {
+					status = http.StatusOK
+				}
+				w.WriteHeader(status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			v := tt.vault
+			v.Address = server.URL + "/"
+			v.Client = server.Client()
+			got, err := v.Value(context.Background())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVaultString(t *testing.T) {
+	v := &Vault{Address: "https://vault:8200", Path: "app/db", Key: "password", Token: func() (string, error) { return "t0ken", nil }}
+	if got, want := v.String(), "vault https://vault:8200/v1/secret/data/app/db key password"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,126 @@
+// Package signature signs and verifies CustomConfigs, so that the operator
+// only applies the ones approved by a release pipeline. The signature is an
+// ed25519 signature of the canonical form of the namespace, name and spec.
+package signature
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+)
+
+// Annotation holds the base64 signature of a CustomConfig
+const Annotation = "mtcil.com/signature"
+
+// signed is what is signed of a CustomConfig, the namespace and name keeping
+// a signed spec from being replayed into another CustomConfig
+type signed struct {
+	Namespace string              `json:"namespace"`
+	Name      string              `json:"name"`
+	Spec      v1.CustomConfigSpec `json:"spec"`
+}
+
+// Canonical returns the canonical form of cc which is signed: the compact
+// JSON of its namespace, name and spec, with the keys of every object
+// sorted. The fields of the spec holding the value defaults gives them are
+// left out, as the defaulting webhook fills them in after the signature of
+// a manifest leaving them empty.
+func Canonical(cc *v1.CustomConfig, defaults v1.CustomConfigDefaultsSpec) ([]byte, error) {
+	spec := cc.Spec.DeepCopy()
+	if defaults.ConfigmapName != "" && spec.ConfigmapName == defaults.ConfigmapName {
+		spec.ConfigmapName = ""
+	}
+	if defaults.TargetNamespace != "" && spec.TargetNamespace == defaults.TargetNamespace {
+		spec.TargetNamespace = ""
+	}
+	if defaults.DeletionPolicy != "" && spec.DeletionPolicy == defaults.DeletionPolicy {
+		spec.DeletionPolicy = ""
+	}
+	data, err := json.Marshal(signed{Namespace: cc.Namespace, Name: cc.Name, Spec: *spec})
+	if err != nil {
+		return nil, err
+	}
+	// maps are encoded with sorted keys, unlike structs
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err = encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// Sign returns the base64 signature of cc by key, cc being the manifest
+// applied, before any default is filled in
+func Sign(key ed25519.PrivateKey, cc *v1.CustomConfig) (string, error) {
+	data, err := Canonical(cc, v1.CustomConfigDefaultsSpec{})
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(key, data)), nil
+}
+
+// Verify checks that the signature annotation of cc was made by one of keys,
+// over cc as it is or with the fields holding their defaults left empty
+func Verify(keys []ed25519.PublicKey, cc *v1.CustomConfig, defaults v1.CustomConfigDefaultsSpec) error {
+	encoded, ok := cc.Annotations[Annotation]
+	if !ok {
+		return fmt.Errorf("customconfig is not signed")
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid %s annotation", Annotation)
+	}
+	for _, d := range []v1.CustomConfigDefaultsSpec{{}, defaults} {
+		data, err := Canonical(cc, d)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if ed25519.Verify(key, data, sig) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("signature does not match the spec or is not made by a trusted key")
+}
+
+// ParsePublicKeys parses the base64 public keys separated by white space
+func ParsePublicKeys(s string) ([]ed25519.PublicKey, error) {
+	var keys []ed25519.PublicKey
+	for _, field := range strings.Fields(s) {
+		data, err := base64.StdEncoding.DecodeString(field)
+		if err != nil || len(data) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid public key %q", field)
+		}
+		keys = append(keys, ed25519.PublicKey(data))
+	}
+	return keys, nil
+}
+
+// ParsePrivateKey parses a base64 private key, either its 32 bytes seed or
+// the 64 bytes of the key
+func ParsePrivateKey(s string) (ed25519.PrivateKey, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %v", err)
+	}
+	switch len(data) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(data), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(data), nil
+	}
+	return nil, fmt.Errorf("invalid private key: %d bytes", len(data))
+}
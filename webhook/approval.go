@@ -0,0 +1,176 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/onkarbanerjee/crd-operator/handler"
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	log "github.com/sirupsen/logrus"
+	admission_v1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+// Approver records who changes and who approves the CustomConfigs, and
+// rejects the approvals of the namespaces requiring approval which do not
+// come from a user other than the author of the approved generation
+type Approver struct {
+	// Namespaces lists the namespaces, none requires approval when it is nil
+	Namespaces corelisters.NamespaceLister
+	// OperatorUser is the user of the operator, whose changes, e.g. a
+	// rollback, are made on behalf of the author of the spec, who stays
+	// recorded
+	OperatorUser string
+}
+
+// Mutate sets the changed-by annotations of the CustomConfig or
+// ClusterCustomConfig created or updated by req to its user and groups when
+// the spec changes, and the approved-by annotation of a CustomConfig when
+// the approval does. They keep their previous value otherwise, they cannot
+// be set by hand.
+func (a *Approver) Mutate(req *admission_v1.AdmissionRequest) *admission_v1.AdmissionResponse {
+	if req.Kind.Kind != "CustomConfig" && req.Kind.Kind != "ClusterCustomConfig" || req.Operation == admission_v1.Delete {
+		return allowed()
+	}
+	obj, old, err := decodeChange(req)
+	if err != nil {
+		return denied(http.StatusBadRequest, meta_v1.StatusReasonBadRequest, err.Error())
+	}
+
+	annotations := map[string]string{}
+	for k, v := range obj.Annotations {
+		annotations[k] = v
+	}
+	user := req.UserInfo.Username
+	for _, key := range []string{handler.ChangedByAnnotation, handler.ChangedByGroupsAnnotation, handler.ApprovedByAnnotation} {
+		setAnnotation(annotations, key, old.Annotations[key])
+	}
+	changed := req.Operation == admission_v1.Create || !equality.Semantic.DeepEqual(old.Spec, obj.Spec)
+	if changed && (user != a.OperatorUser || req.Operation == admission_v1.Create) {
+		setAnnotation(annotations, handler.ChangedByAnnotation, user)
+		setAnnotation(annotations, handler.ChangedByGroupsAnnotation, strings.Join(req.UserInfo.Groups, ","))
+	}
+	if approval := annotations[handler.ApproveAnnotation]; req.Kind.Kind == "CustomConfig" && approval != old.Annotations[handler.ApproveAnnotation] {
+		by := user
+		if approval == "" {
+			by = ""
+		}
+		setAnnotation(annotations, handler.ApprovedByAnnotation, by)
+	}
+	if equality.Semantic.DeepEqual(annotations, obj.Annotations) {
+		return allowed()
+	}
+
+	patch, err := json.Marshal([]patchOperation{{Op: "add", Path: "/metadata/annotations", Value: annotations}})
+	if err != nil {
+		log.Error("error is", err)
+		return denied(http.StatusInternalServerError, meta_v1.StatusReasonInternalError, err.Error())
+	}
+	patchType := admission_v1.PatchTypeJSONPatch
+	return &admission_v1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patch,
+		PatchType: &patchType,
+	}
+}
+
+// Validate rejects the approval set on the CustomConfig created or updated
+// by req, in a namespace requiring approval, when it does not approve the
+// current generation or comes from the user who made it
+func (a *Approver) Validate(req *admission_v1.AdmissionRequest) *admission_v1.AdmissionResponse {
+	if req.Kind.Kind != "CustomConfig" || req.Operation == admission_v1.Delete {
+		return allowed()
+	}
+	cc, old, err := decodeRequest(req)
+	if err != nil {
+		return denied(http.StatusBadRequest, meta_v1.StatusReasonBadRequest, err.Error())
+	}
+	approval := cc.Annotations[handler.ApproveAnnotation]
+	if approval == "" || approval == old.Annotations[handler.ApproveAnnotation] || !a.requiresApproval(cc.Namespace) {
+		return allowed()
+	}
+
+	generation, err := strconv.ParseInt(approval, 10, 64)
+	if err != nil {
+		return denied(http.StatusUnprocessableEntity, meta_v1.StatusReasonInvalid, fmt.Sprintf("annotation %s of customconfig %s/%s must be the generation approved", handler.ApproveAnnotation, cc.Namespace, cc.Name))
+	}
+	if generation != cc.Generation {
+		return denied(http.StatusConflict, meta_v1.StatusReasonConflict, fmt.Sprintf("customconfig %s/%s is at generation %d, not %d", cc.Namespace, cc.Name, cc.Generation, generation))
+	}
+	by, author := cc.Annotations[handler.ApprovedByAnnotation], cc.Annotations[handler.ChangedByAnnotation]
+	if by == author {
+		log.Infof("rejecting the approval of customconfig %s/%s by its author %s", cc.Namespace, cc.Name, by)
+		return denied(http.StatusForbidden, meta_v1.StatusReasonForbidden, fmt.Sprintf("generation %d of customconfig %s/%s was made by %s, who cannot approve it", generation, cc.Namespace, cc.Name, author))
+	}
+	log.Infof("generation %d of customconfig %s/%s approved by %s", generation, cc.Namespace, cc.Name, by)
+	return allowed()
+}
+
+// requiresApproval tells whether the CustomConfig changes in ns must be
+// approved
+func (a *Approver) requiresApproval(ns string) bool {
+	if a.Namespaces == nil {
+		return false
+	}
+	namespace, err := a.Namespaces.Get(ns)
+	if err != nil {
+		log.Error("error is", err)
+		return false
+	}
+	return handler.RequiresApproval(namespace)
+}
+
+// decodeRequest returns the CustomConfig of req and the one it replaces,
+// which is empty for a create
+func decodeRequest(req *admission_v1.AdmissionRequest) (*v1.CustomConfig, *v1.CustomConfig, error) {
+	cc, old := &v1.CustomConfig{}, &v1.CustomConfig{}
+	if err := json.Unmarshal(req.Object.Raw, cc); err != nil {
+		return nil, nil, fmt.Errorf("decoding customconfig: %v", err)
+	}
+	if req.Operation == admission_v1.Update {
+		if err := json.Unmarshal(req.OldObject.Raw, old); err != nil {
+			return nil, nil, fmt.Errorf("decoding customconfig: %v", err)
+		}
+	}
+	// the namespace is not always set in the object of a create
+	if cc.Namespace == "" {
+		cc.Namespace = req.Namespace
+	}
+	return cc, old, nil
+}
+
+// change is the metadata and spec of any kind of object
+type change struct {
+	meta_v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec               interface{} `json:"spec,omitempty"`
+}
+
+// decodeChange returns the metadata and spec of the object of req and of the
+// one it replaces, which is empty for a create
+func decodeChange(req *admission_v1.AdmissionRequest) (*change, *change, error) {
+	obj, old := &change{}, &change{}
+	if err := json.Unmarshal(req.Object.Raw, obj); err != nil {
+		return nil, nil, fmt.Errorf("decoding %s: %v", strings.ToLower(req.Kind.Kind), err)
+	}
+	if req.Operation == admission_v1.Update {
+		if err := json.Unmarshal(req.OldObject.Raw, old); err != nil {
+			return nil, nil, fmt.Errorf("decoding %s: %v", strings.ToLower(req.Kind.Kind), err)
+		}
+	}
+	return obj, old, nil
+}
+
+// setAnnotation sets the annotation key to value, removing it when value is
+// empty
+func setAnnotation(annotations map[string]string, key, value string) {
+	if value == "" {
+		delete(annotations, key)
+		return
+	}
+	annotations[key] = value
+}
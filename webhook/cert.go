@@ -0,0 +1,228 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	admissionregistration_v1 "k8s.io/api/admissionregistration/v1"
+	errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	certFile = "tls.crt"
+	keyFile  = "tls.key"
+	caFile   = "ca.crt"
+
+	// certValidity is how long a self-signed certificate is valid
+	certValidity = 365 * 24 * time.Hour
+)
+
+// Certificate loads the serving certificate of the webhooks from the
+// tls.crt and tls.key files of dir and the CA it is signed by from ca.crt.
+// When there are none and selfSigned is set, a CA and a certificate for
+// hosts signed by it are generated and written to dir first, which is meant
+// for local testing.
+func Certificate(dir string, hosts []string, selfSigned bool) (tls.Certificate, []byte, error) {
+	certPath, keyPath := filepath.Join(dir, certFile), filepath.Join(dir, keyFile)
+	if _, err := os.Stat(certPath); os.IsNotExist(err) && selfSigned {
+		if err = writeSelfSigned(dir, hosts); err != nil {
+			return tls.Certificate{}, nil, fmt.Errorf("generating self-signed certificate: %v", err)
+		}
+		log.Infof("generated self-signed webhook certificate for %v in %s", hosts, dir)
+	}
+
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	ca, err := ioutil.ReadFile(filepath.Join(dir, caFile))
+	if err != nil && !os.IsNotExist(err) {
+		return tls.Certificate{}, nil, err
+	}
+	return cert, ca, nil
+}
+
+// writeSelfSigned writes a new CA and a certificate for hosts signed by it
+// to dir
+func writeSelfSigned(dir string, hosts []string) error {
+	notBefore := time.Now().Add(-time.Hour)
+	notAfter := notBefore.Add(certValidity)
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          serialNumber(),
+		Subject:               pkix.Name{CommonName: "crd-operator-webhook-ca"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serialNumber(),
+		Subject:      pkix.Name{CommonName: hosts[0]},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	files := map[string]*pem.Block{
+		caFile:   {Type: "CERTIFICATE", Bytes: caDER},
+		certFile: {Type: "CERTIFICATE", Bytes: der},
+		keyFile:  {Type: "EC PRIVATE KEY", Bytes: keyDER},
+	}
+	for name, block := range files {
+		if err = ioutil.WriteFile(filepath.Join(dir, name), pem.EncodeToMemory(block), 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serialNumber returns a random certificate serial number
+func serialNumber() *big.Int {
+	n, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		log.Error("error is", err)
+		return big.NewInt(time.Now().UnixNano())
+	}
+	return n
+}
+
+// InjectCABundle sets ca as the CA bundle of every webhook of the
+// ValidatingWebhookConfiguration and the MutatingWebhookConfiguration name,
+// so that the apiserver trusts a self-signed certificate
+func InjectCABundle(client kubernetes.Interface, name string, ca []byte) error {
+	validating := client.AdmissionregistrationV1().ValidatingWebhookConfigurations()
+	vwc, err := validating.Get(name, meta_v1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		log.Warnf("validating webhook configuration %s not found, not injecting its CA bundle", name)
+	case err != nil:
+		return err
+	default:
+		changed := false
+		for i := range vwc.Webhooks {
+			changed = setCABundle(&vwc.Webhooks[i].ClientConfig, ca) || changed
+		}
+		if changed {
+			if _, err = validating.Update(vwc); err != nil {
+				return err
+			}
+			log.Infof("injected CA bundle into validating webhook configuration %s", name)
+		}
+	}
+
+	mutating := client.AdmissionregistrationV1().MutatingWebhookConfigurations()
+	mwc, err := mutating.Get(name, meta_v1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		log.Warnf("mutating webhook configuration %s not found, not injecting its CA bundle", name)
+	case err != nil:
+		return err
+	default:
+		changed := false
+		for i := range mwc.Webhooks {
+			changed = setCABundle(&mwc.Webhooks[i].ClientConfig, ca) || changed
+		}
+		if changed {
+			if _, err = mutating.Update(mwc); err != nil {
+				return err
+			}
+			log.Infof("injected CA bundle into mutating webhook configuration %s", name)
+		}
+	}
+	return nil
+}
+
+// setCABundle sets ca as the CA bundle of config and tells whether it changed
+func setCABundle(config *admissionregistration_v1.WebhookClientConfig, ca []byte) bool {
+	if bytes.Equal(config.CABundle, ca) {
+		return false
+	}
+	config.CABundle = ca
+	return true
+}
+
+// InjectConversionCABundle sets ca as the CA bundle of the conversion
+// webhook of the CustomResourceDefinition name
+func InjectConversionCABundle(client kubernetes.Interface, name string, ca []byte) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"conversion": map[string]interface{}{
+				"webhookClientConfig": map[string]interface{}{
+					"caBundle": ca,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	err = client.Discovery().RESTClient().Patch(types.MergePatchType).
+		AbsPath("/apis/apiextensions.k8s.io/v1beta1/customresourcedefinitions", name).
+		Body(patch).
+		Do().
+		Error()
+	if errors.IsNotFound(err) {
+		log.Warnf("custom resource definition %s not found, not injecting its CA bundle", name)
+		return nil
+	}
+	if err == nil {
+		log.Infof("injected CA bundle into the conversion webhook of custom resource definition %s", name)
+	}
+	return err
+}
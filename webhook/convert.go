@@ -0,0 +1,149 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig"
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	v2 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v2"
+	log "github.com/sirupsen/logrus"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// conversionReview is the apiextensions.k8s.io ConversionReview, whose v1
+// and v1beta1 have the same fields
+type conversionReview struct {
+	meta_v1.TypeMeta `json:",inline"`
+	Request          *conversionRequest  `json:"request,omitempty"`
+	Response         *conversionResponse `json:"response,omitempty"`
+}
+
+type conversionRequest struct {
+	UID               types.UID              `json:"uid"`
+	DesiredAPIVersion string                 `json:"desiredAPIVersion"`
+	Objects           []runtime.RawExtension `json:"objects"`
+}
+
+type conversionResponse struct {
+	UID              types.UID              `json:"uid"`
+	ConvertedObjects []runtime.RawExtension `json:"convertedObjects"`
+	Result           meta_v1.Status         `json:"result"`
+}
+
+// customConfigVersions returns an empty CustomConfig of each API version
+var customConfigVersions = map[string]func() runtime.Object{
+	v1.SchemeGroupVersion.String(): func() runtime.Object { return &v1.CustomConfig{} },
+	v2.SchemeGroupVersion.String(): func() runtime.Object { return &v2.CustomConfig{} },
+}
+
+// Converter converts CustomConfigs between their API versions through the
+// v2 hub, for the conversion webhook of the CustomResourceDefinition
+type Converter struct{}
+
+// ServeHTTP answers a ConversionReview
+func (c *Converter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	review := conversionReview{}
+	if err = json.Unmarshal(body, &review); err != nil || review.Request == nil {
+		http.Error(w, fmt.Sprintf("invalid conversion review: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	response := &conversionResponse{
+		UID:    review.Request.UID,
+		Result: meta_v1.Status{Status: meta_v1.StatusSuccess},
+	}
+	for _, obj := range review.Request.Objects {
+		converted, err := c.Convert(obj.Raw, review.Request.DesiredAPIVersion)
+		if err != nil {
+			log.Errorf("converting to %s: %v", review.Request.DesiredAPIVersion, err)
+			response.ConvertedObjects = nil
+			response.Result = meta_v1.Status{Status: meta_v1.StatusFailure, Message: err.Error()}
+			break
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	review.Request = nil
+	review.Response = response
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(review); err != nil {
+		log.Error("error is", err)
+	}
+}
+
+// Convert converts the CustomConfig raw to the API version desired
+func (c *Converter) Convert(raw []byte, desired string) ([]byte, error) {
+	meta := meta_v1.TypeMeta{}
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return nil, err
+	}
+	if meta.Kind != "CustomConfig" {
+		return nil, fmt.Errorf("cannot convert kind %q", meta.Kind)
+	}
+	if meta.APIVersion == desired {
+		return raw, nil
+	}
+	newSrc, ok := customConfigVersions[meta.APIVersion]
+	if !ok {
+		return nil, fmt.Errorf("unknown version %q", meta.APIVersion)
+	}
+	newDst, ok := customConfigVersions[desired]
+	if !ok {
+		return nil, fmt.Errorf("unknown version %q", desired)
+	}
+
+	src, dst := newSrc(), newDst()
+	if err := json.Unmarshal(raw, src); err != nil {
+		return nil, err
+	}
+	if err := convert(src, dst); err != nil {
+		return nil, err
+	}
+	gv, err := schema.ParseGroupVersion(desired)
+	if err != nil {
+		return nil, err
+	}
+	dst.GetObjectKind().SetGroupVersionKind(gv.WithKind(meta.Kind))
+	return json.Marshal(dst)
+}
+
+// convert converts src to dst, going through the hub when neither is it
+func convert(src, dst runtime.Object) error {
+	switch s := src.(type) {
+	case customconfig.Hub:
+		d, ok := dst.(customconfig.Convertible)
+		if !ok {
+			return fmt.Errorf("%T does not convert from the hub", dst)
+		}
+		return d.ConvertFrom(s)
+	case customconfig.Convertible:
+		if d, ok := dst.(customconfig.Hub); ok {
+			return s.ConvertTo(d)
+		}
+		d, ok := dst.(customconfig.Convertible)
+		if !ok {
+			return fmt.Errorf("%T does not convert from the hub", dst)
+		}
+		hub := &v2.CustomConfig{}
+		if err := s.ConvertTo(hub); err != nil {
+			return err
+		}
+		return d.ConvertFrom(hub)
+	}
+	return fmt.Errorf("%T does not convert to the hub", src)
+}
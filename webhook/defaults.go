@@ -0,0 +1,135 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	listers "github.com/onkarbanerjee/crd-operator/pkg/client/listers/customconfig/v1"
+	log "github.com/sirupsen/logrus"
+	admission_v1 "k8s.io/api/admission/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// patchOperation is an operation of a JSON patch
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Defaulter fills the fields a CustomConfig leaves empty with the
+// CustomConfigDefaults of its namespace, falling back to the operator-wide
+// Defaults
+type Defaulter struct {
+	// Lister lists the CustomConfigDefaults, only Defaults apply when it is nil
+	Lister listers.CustomConfigDefaultsLister
+	// Defaults are the operator-wide defaults
+	Defaults v1.CustomConfigDefaultsSpec
+}
+
+// Admit defaults the CustomConfig created or updated by req
+func (d *Defaulter) Admit(req *admission_v1.AdmissionRequest) *admission_v1.AdmissionResponse {
+	if req.Kind.Kind != "CustomConfig" || req.Operation == admission_v1.Delete {
+		return allowed()
+	}
+
+	cc := &v1.CustomConfig{}
+	if err := json.Unmarshal(req.Object.Raw, cc); err != nil {
+		return denied(http.StatusBadRequest, meta_v1.StatusReasonBadRequest, fmt.Sprintf("decoding customconfig: %v", err))
+	}
+	if cc.Namespace == "" {
+		cc.Namespace = req.Namespace
+	}
+	// a spec without any field may not be there at all
+	var object map[string]interface{}
+	if err := json.Unmarshal(req.Object.Raw, &object); err != nil {
+		return denied(http.StatusBadRequest, meta_v1.StatusReasonBadRequest, fmt.Sprintf("decoding customconfig: %v", err))
+	}
+
+	var ops []patchOperation
+	for _, def := range d.defaults(cc) {
+		if def.current == "" && def.value != "" {
+			ops = append(ops, patchOperation{Op: "add", Path: "/spec/" + def.field, Value: def.value})
+		}
+	}
+	if len(ops) == 0 {
+		return allowed()
+	}
+	if _, ok := object["spec"]; !ok {
+		ops = append([]patchOperation{{Op: "add", Path: "/spec", Value: map[string]interface{}{}}}, ops...)
+	}
+
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		log.Error("error is", err)
+		return denied(http.StatusInternalServerError, meta_v1.StatusReasonInternalError, err.Error())
+	}
+	log.Infof("defaulting customconfig %s/%s: %s", cc.Namespace, cc.Name, patch)
+	patchType := admission_v1.PatchTypeJSONPatch
+	return &admission_v1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patch,
+		PatchType: &patchType,
+	}
+}
+
+// fieldDefault is a field of the spec of a CustomConfig, its current value
+// and its default
+type fieldDefault struct {
+	field   string
+	current string
+	value   string
+}
+
+// defaults returns the defaults of the fields of cc
+func (d *Defaulter) defaults(cc *v1.CustomConfig) []fieldDefault {
+	defaults := d.Effective(cc.Namespace)
+	return []fieldDefault{
+		{"configmapName", cc.Spec.ConfigmapName, defaults.ConfigmapName},
+		{"targetNamespace", cc.Spec.TargetNamespace, defaults.TargetNamespace},
+		{"deletionPolicy", string(cc.Spec.DeletionPolicy), string(defaults.DeletionPolicy)},
+	}
+}
+
+// Effective returns the defaults of the CustomConfigs of ns, the ones of the
+// CustomConfigDefaults of ns taking precedence over Defaults field by field
+func (d *Defaulter) Effective(ns string) v1.CustomConfigDefaultsSpec {
+	defaults := d.namespaceDefaults(ns)
+	if defaults.ConfigmapName == "" {
+		defaults.ConfigmapName = d.Defaults.ConfigmapName
+	}
+	if defaults.TargetNamespace == "" {
+		defaults.TargetNamespace = d.Defaults.TargetNamespace
+	}
+	if defaults.DeletionPolicy == "" {
+		defaults.DeletionPolicy = d.Defaults.DeletionPolicy
+	}
+	return defaults
+}
+
+// namespaceDefaults returns the spec of the CustomConfigDefaults of ns, the
+// first one by name when there are several
+func (d *Defaulter) namespaceDefaults(ns string) v1.CustomConfigDefaultsSpec {
+	if d.Lister == nil {
+		return v1.CustomConfigDefaultsSpec{}
+	}
+	defaults, err := d.Lister.CustomConfigDefaults(ns).List(labels.Everything())
+	if err != nil {
+		log.Error("error is", err)
+		return v1.CustomConfigDefaultsSpec{}
+	}
+	if len(defaults) == 0 {
+		return v1.CustomConfigDefaultsSpec{}
+	}
+	sort.Slice(defaults, func(i, j int) bool {
+		return defaults[i].Name < defaults[j].Name
+	})
+	if len(defaults) > 1 {
+		log.Warnf("namespace %s has %d customconfigdefaults, using %s", ns, len(defaults), defaults[0].Name)
+	}
+	return defaults[0].Spec
+}
@@ -0,0 +1,129 @@
+package webhook
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+	admission_v1 "k8s.io/api/admission/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// admitFunc decides on a single admission request
+type admitFunc func(req *admission_v1.AdmissionRequest) *admission_v1.AdmissionResponse
+
+// Server serves the admission webhooks of the operator over HTTPS
+type Server struct {
+	// Validator validates the CustomConfigs
+	Validator *Validator
+	// Defaulter defaults the fields the CustomConfigs leave empty
+	Defaulter *Defaulter
+	// Approver records who changes the CustomConfigs and
+	// ClusterCustomConfigs, and who approves the CustomConfigs, and
+	// validates the approvals
+	Approver *Approver
+	// Converter converts the CustomConfigs between their API versions
+	Converter *Converter
+
+	mux *http.ServeMux
+}
+
+// Handle registers the admission webhook admit at path
+func (s *Server) Handle(path string, admit admitFunc) {
+	if s.mux == nil {
+		s.mux = http.NewServeMux()
+	}
+	s.mux.Handle(path, admissionHandler(admit))
+}
+
+// Run serves the webhooks on addr with cert until stopCh is closed
+func (s *Server) Run(addr string, cert tls.Certificate, stopCh <-chan struct{}) {
+	if s.mux == nil {
+		s.mux = http.NewServeMux()
+	}
+	if s.Validator != nil {
+		s.Handle("/validate-customconfig", s.Validator.Admit)
+	}
+	if s.Defaulter != nil {
+		s.Handle("/mutate-customconfig", s.Defaulter.Admit)
+	}
+	if s.Approver != nil {
+		s.Handle("/approve-customconfig", s.Approver.Mutate)
+		s.Handle("/validate-customconfig-approval", s.Approver.Validate)
+	}
+	if s.Converter != nil {
+		s.mux.Handle("/convert", s.Converter)
+	}
+
+	server := &http.Server{
+		Addr:    addr,
+		Handler: s.mux,
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		},
+	}
+	go func() {
+		<-stopCh
+		server.Close()
+	}()
+
+	log.Infof("serving webhooks on %s", addr)
+	if err := server.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("serving webhooks: %v", err)
+	}
+}
+
+// admissionHandler decodes the AdmissionReview of a request, passes it to
+// admit and answers with an AdmissionReview of the same version
+func admissionHandler(admit admitFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// v1 and v1beta1 AdmissionReviews have the same fields
+		review := admission_v1.AdmissionReview{}
+		if err = json.Unmarshal(body, &review); err != nil || review.Request == nil {
+			http.Error(w, fmt.Sprintf("invalid admission review: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		response := admit(review.Request)
+		response.UID = review.Request.UID
+		review.Response = response
+		review.Request = nil
+
+		w.Header().Set("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(review); err != nil {
+			log.Error("error is", err)
+		}
+	})
+}
+
+// allowed admits a request
+func allowed() *admission_v1.AdmissionResponse {
+	return &admission_v1.AdmissionResponse{Allowed: true}
+}
+
+// denied rejects a request with the given reason and message
+func denied(code int32, reason meta_v1.StatusReason, message string) *admission_v1.AdmissionResponse {
+	return &admission_v1.AdmissionResponse{
+		Allowed: false,
+		Result: &meta_v1.Status{
+			Status:  meta_v1.StatusFailure,
+			Code:    code,
+			Reason:  reason,
+			Message: message,
+		},
+	}
+}
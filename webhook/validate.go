@@ -0,0 +1,327 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"github.com/onkarbanerjee/crd-operator/encryption"
+	"github.com/onkarbanerjee/crd-operator/handler"
+	v1 "github.com/onkarbanerjee/crd-operator/pkg/apis/customconfig/v1"
+	listers "github.com/onkarbanerjee/crd-operator/pkg/client/listers/customconfig/v1"
+	log "github.com/sirupsen/logrus"
+	admission_v1 "k8s.io/api/admission/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// maxValueSize is the most data a ConfigMap can hold, which a single value
+// cannot exceed
+const maxValueSize = 1024 * 1024
+
+// Validator rejects the CustomConfigs the operator would fail to apply
+type Validator struct {
+	// Lister lists the CustomConfigs the key of a CustomConfig may clash
+	// with, keys are not checked for clashes when it is nil
+	Lister listers.CustomConfigLister
+	// Schemas lists the ConfigSchemas the CustomConfigs are validated
+	// against, none is when it is nil
+	Schemas listers.ConfigSchemaLister
+	// Decrypter checks that the encrypted values can be decrypted, they are
+	// not when it is nil
+	Decrypter *encryption.Key
+	// Policy protects namespaces, ConfigMaps and keys from the CustomConfigs
+	Policy v1.CustomConfigPolicySpec
+	// Policies lists the CustomConfigPolicies adding to Policy, none does
+	// when it is nil
+	Policies listers.CustomConfigPolicyLister
+}
+
+// Admit validates the CustomConfig created or updated by req
+func (v *Validator) Admit(req *admission_v1.AdmissionRequest) *admission_v1.AdmissionResponse {
+	if req.Kind.Kind != "CustomConfig" || req.Operation == admission_v1.Delete {
+		return allowed()
+	}
+
+	cc := &v1.CustomConfig{}
+	if err := json.Unmarshal(req.Object.Raw, cc); err != nil {
+		return denied(http.StatusBadRequest, meta_v1.StatusReasonBadRequest, fmt.Sprintf("decoding customconfig: %v", err))
+	}
+	// the namespace is not always set in the object of a create
+	if cc.Namespace == "" {
+		cc.Namespace = req.Namespace
+	}
+
+	errs := v.Validate(cc)
+	if len(errs) > 0 {
+		log.Infof("rejecting customconfig %s/%s: %v", cc.Namespace, cc.Name, errs.ToAggregate())
+		return denied(http.StatusUnprocessableEntity, meta_v1.StatusReasonInvalid, fmt.Sprintf("customconfig %s/%s is invalid: %v", cc.Namespace, cc.Name, errs.ToAggregate()))
+	}
+	return allowed()
+}
+
+// Validate checks cc on its own and against the other CustomConfigs
+func (v *Validator) Validate(cc *v1.CustomConfig) field.ErrorList {
+	spec := field.NewPath("spec")
+	var errs field.ErrorList
+
+	switch {
+	case cc.Spec.EncryptedValue != "":
+		// an encrypted value is written to a secret, the config map is not
+		if cc.Spec.SecretName == "" {
+			errs = append(errs, field.Required(spec.Child("secretName"), "the secret to write the encrypted value to must be named"))
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(cc.Spec.SecretName) {
+			errs = append(errs, field.Invalid(spec.Child("secretName"), cc.Spec.SecretName, msg))
+		}
+	case cc.Spec.ConfigmapName == "":
+		errs = append(errs, field.Required(spec.Child("configmapName"), "the config map to write to must be named"))
+	default:
+		for _, msg := range validation.IsDNS1123Subdomain(cc.Spec.ConfigmapName) {
+			errs = append(errs, field.Invalid(spec.Child("configmapName"), cc.Spec.ConfigmapName, msg))
+		}
+	}
+
+	if cc.Spec.TargetNamespace != "" {
+		for _, msg := range validation.IsDNS1123Label(cc.Spec.TargetNamespace) {
+			errs = append(errs, field.Invalid(spec.Child("targetNamespace"), cc.Spec.TargetNamespace, msg))
+		}
+	}
+	policy, err := handler.EffectivePolicy(v.Policy, v.Policies)
+	if err != nil {
+		log.Error("error is", err)
+	}
+	if path, err := handler.CheckPolicy(policy, cc); err != nil {
+		errs = append(errs, field.Forbidden(path, err.Error()))
+	}
+
+	if cc.Spec.Key == "" {
+		errs = append(errs, field.Required(spec.Child("key"), ""))
+	}
+	if cc.Spec.File != nil {
+		// the key is an entry of the file, the file is the config map key
+		if cc.Spec.File.Name == "" {
+			errs = append(errs, field.Required(spec.Child("file", "name"), ""))
+		} else {
+			for _, msg := range validation.IsConfigMapKey(cc.Spec.File.Name) {
+				errs = append(errs, field.Invalid(spec.Child("file", "name"), cc.Spec.File.Name, msg))
+			}
+		}
+	} else if cc.Spec.Key != "" {
+		for _, msg := range validation.IsConfigMapKey(cc.Spec.Key) {
+			errs = append(errs, field.Invalid(spec.Child("key"), cc.Spec.Key, msg))
+		}
+	}
+
+	if len(cc.Spec.Value) > maxValueSize {
+		errs = append(errs, field.TooLong(spec.Child("value"), fmt.Sprintf("%d bytes", len(cc.Spec.Value)), maxValueSize))
+	}
+	if cc.Spec.EncryptedValue != "" {
+		errs = append(errs, v.validateEncrypted(cc)...)
+	} else if cc.Spec.ValueFrom != nil {
+		// an external value is only checked once it is fetched
+		if cc.Spec.Value != "" {
+			errs = append(errs, field.Forbidden(spec.Child("value"), "value and valueFrom are exclusive"))
+		}
+		errs = append(errs, validateValueFrom(cc.Spec.ValueFrom, spec.Child("valueFrom"))...)
+		if cc.Spec.ConfigmapName != "" {
+			errs = append(errs, v.validateSchemas(cc, nil)...)
+		}
+	} else {
+		normalized, err := handler.NormalizeValue(cc.Spec.Type, cc.Spec.Constraints, cc.Spec.Value)
+		if err != nil {
+			errs = append(errs, field.Invalid(spec.Child("value"), cc.Spec.Value, err.Error()))
+		} else if cc.Spec.ConfigmapName != "" {
+			errs = append(errs, v.validateSchemas(cc, &normalized)...)
+		}
+	}
+
+	if len(errs) == 0 {
+		errs = append(errs, v.validateOwnership(cc)...)
+	}
+	return errs
+}
+
+// validateEncrypted checks that the encrypted value of cc is the only value
+// it sets, and that the operator can decrypt it. The errors never quote the
+// decrypted value.
+func (v *Validator) validateEncrypted(cc *v1.CustomConfig) field.ErrorList {
+	spec := field.NewPath("spec")
+	var errs field.ErrorList
+	if cc.Spec.Value != "" {
+		errs = append(errs, field.Forbidden(spec.Child("value"), "value and encryptedValue are exclusive"))
+	}
+	if cc.Spec.ValueFrom != nil {
+		errs = append(errs, field.Forbidden(spec.Child("valueFrom"), "valueFrom and encryptedValue are exclusive"))
+	}
+	if cc.Spec.File != nil {
+		errs = append(errs, field.Forbidden(spec.Child("file"), "an encrypted value is written to a secret key of its own"))
+	}
+	if cc.Spec.Strategy == v1.StrategyMerge {
+		errs = append(errs, field.Forbidden(spec.Child("strategy"), "an encrypted value is not merged"))
+	}
+	if cc.Spec.Immutable != nil {
+		errs = append(errs, field.Forbidden(spec.Child("immutable"), "an encrypted value is written to a secret"))
+	}
+
+	if v.Decrypter == nil {
+		return errs
+	}
+	value, err := v.Decrypter.Decrypt(cc.Spec.EncryptedValue)
+	if err != nil {
+		return append(errs, field.Invalid(spec.Child("encryptedValue"), "", err.Error()))
+	}
+	if _, err = handler.NormalizeValue(cc.Spec.Type, cc.Spec.Constraints, value); err != nil {
+		errs = append(errs, field.Invalid(spec.Child("encryptedValue"), "", fmt.Sprintf("the decrypted value is not a valid %s value", cc.Spec.Type)))
+	}
+	return errs
+}
+
+// validateValueFrom checks that from names exactly one complete source
+func validateValueFrom(from *v1.ValueSource, path *field.Path) field.ErrorList {
+	ext := from.External
+	if ext == nil {
+		return field.ErrorList{field.Required(path.Child("external"), "")}
+	}
+	path = path.Child("external")
+
+	var errs field.ErrorList
+	sources := 0
+	if ext.File != nil {
+		sources++
+		if !filepath.IsAbs(ext.File.Path) {
+			errs = append(errs, field.Invalid(path.Child("file", "path"), ext.File.Path, "must be an absolute path"))
+		}
+	}
+	if ext.HTTP != nil {
+		sources++
+		errs = append(errs, validateURL(ext.HTTP.URL, path.Child("http", "url"))...)
+	}
+	if ext.Vault != nil {
+		sources++
+		vault := path.Child("vault")
+		errs = append(errs, validateURL(ext.Vault.Address, vault.Child("address"))...)
+		if ext.Vault.Path == "" {
+			errs = append(errs, field.Required(vault.Child("path"), ""))
+		}
+		if ext.Vault.Key == "" {
+			errs = append(errs, field.Required(vault.Child("key"), ""))
+		}
+		if ext.Vault.Version != 0 && ext.Vault.Version != 1 && ext.Vault.Version != 2 {
+			errs = append(errs, field.NotSupported(vault.Child("version"), ext.Vault.Version, []string{"1", "2"}))
+		}
+	}
+	if sources != 1 {
+		errs = append(errs, field.Invalid(path, sources, "exactly one of file, http and vault must be set"))
+	}
+	if ext.PollInterval != nil && ext.PollInterval.Duration < time.Second {
+		errs = append(errs, field.Invalid(path.Child("pollInterval"), ext.PollInterval.Duration.String(), "must be at least 1s"))
+	}
+	return errs
+}
+
+// validateURL checks that s is an absolute http or https URL
+func validateURL(s string, path *field.Path) field.ErrorList {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return field.ErrorList{field.Invalid(path, s, "must be an http or https URL")}
+	}
+	return nil
+}
+
+// validateSchemas rejects cc when the ConfigSchemas of its ConfigMap do not
+// declare its key or when its value does not match the one declared
+func (v *Validator) validateSchemas(cc *v1.CustomConfig, value *string) field.ErrorList {
+	path := field.NewPath("spec", "key")
+	if cc.Spec.File != nil {
+		path = field.NewPath("spec", "file", "name")
+	}
+	schemas, err := handler.SchemasOf(v.Schemas, cc)
+	if err != nil {
+		log.Error("error is", err)
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+	if err = handler.CheckSchemas(schemas, cc, value); err != nil {
+		return field.ErrorList{field.Invalid(path, handler.ConfigMapKey(cc), err.Error())}
+	}
+	return nil
+}
+
+// claim is the way a CustomConfig writes to a ConfigMap key
+type claim struct {
+	key string
+	// merge is set for a fragment merged with others into the key
+	merge bool
+	// entry is the entry a file contributor sets within the key
+	entry string
+	file  bool
+}
+
+// claimOf returns the claim of cc on its ConfigMap key
+func claimOf(cc *v1.CustomConfig) claim {
+	if cc.Spec.File != nil {
+		return claim{key: cc.Spec.File.Name, file: true, entry: cc.Spec.Key}
+	}
+	return claim{key: cc.Spec.Key, merge: cc.Spec.Strategy == v1.StrategyMerge}
+}
+
+// targetOf describes the object cc writes to, e.g. config map ns/name
+func targetOf(cc *v1.CustomConfig) string {
+	if cc.Spec.EncryptedValue != "" {
+		return "secret " + handler.TargetNamespace(cc) + "/" + cc.Spec.SecretName
+	}
+	return "config map " + handler.TargetNamespace(cc) + "/" + cc.Spec.ConfigmapName
+}
+
+// clashes tells whether two CustomConfigs with the claims c and other on
+// the same key would overwrite each other. Fragments merge with each other
+// and the entries of a file add up, unless they set the same entry.
+func (c claim) clashes(other claim) bool {
+	switch {
+	case c.merge && other.merge:
+		return false
+	case c.file && other.file:
+		return c.entry == other.entry
+	}
+	return true
+}
+
+// validateOwnership rejects cc when another CustomConfig already writes to
+// the same ConfigMap key in a way the key of cc would clash with
+func (v *Validator) validateOwnership(cc *v1.CustomConfig) field.ErrorList {
+	if v.Lister == nil {
+		return nil
+	}
+	ccs, err := v.Lister.List(labels.Everything())
+	if err != nil {
+		log.Error("error is", err)
+		return field.ErrorList{field.InternalError(field.NewPath("spec", "key"), err)}
+	}
+
+	target, mine := targetOf(cc), claimOf(cc)
+	path := field.NewPath("spec", "key")
+	if mine.file {
+		path = field.NewPath("spec", "file", "name")
+	}
+	var errs field.ErrorList
+	for _, other := range ccs {
+		if other.Namespace == cc.Namespace && other.Name == cc.Name {
+			continue
+		}
+		if targetOf(other) != target {
+			continue
+		}
+		theirs := claimOf(other)
+		if theirs.key != mine.key || !mine.clashes(theirs) {
+			continue
+		}
+		errs = append(errs, field.Forbidden(path, fmt.Sprintf("key %s of %s is already written by customconfig %s/%s", mine.key, target, other.Namespace, other.Name)))
+		break
+	}
+	return errs
+}
@@ -0,0 +1,88 @@
+package workload
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ChecksumAnnotation is stamped on the pod template of the workloads
+// consuming a ConfigMap, so that changing it rolls their pods
+const ChecksumAnnotation = "mtcil.com/config-checksum"
+
+// Restarter rolls the workloads consuming a ConfigMap whenever its content
+// changes
+type Restarter struct {
+	Client kubernetes.Interface
+	// Debounce is how long RestartDebounced waits for the ConfigMap to
+	// settle before rolling its consumers
+	Debounce time.Duration
+
+	lock   sync.Mutex
+	timers map[string]*time.Timer
+}
+
+// Restart stamps checksum on the pod template of every workload of the
+// namespace referring to the ConfigMap. Workloads already carrying the
+// checksum are left alone.
+func (r *Restarter) Restart(ns, configmapName, checksum string) error {
+	workloads, err := List(r.Client, ns, labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{
+						ChecksumAnnotation: checksum,
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, w := range workloads {
+		if !w.ReferencesConfigMap(configmapName) || w.Template.Annotations[ChecksumAnnotation] == checksum {
+			continue
+		}
+		if err = w.Patch(r.Client, patch); err != nil {
+			log.Errorf("restarting %s %s/%s: %v", w.Kind, ns, w.Name, err)
+			continue
+		}
+		log.Infof("restarting %s %s/%s for config map %s", w.Kind, ns, w.Name, configmapName)
+	}
+	return nil
+}
+
+// RestartDebounced calls Restart once the ConfigMap has not changed for the
+// Debounce period, using the checksum of its latest change
+func (r *Restarter) RestartDebounced(ns, configmapName, checksum string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.timers == nil {
+		r.timers = map[string]*time.Timer{}
+	}
+	key := ns + "/" + configmapName
+	if timer, ok := r.timers[key]; ok {
+		timer.Stop()
+	}
+	r.timers[key] = time.AfterFunc(r.Debounce, func() {
+		r.lock.Lock()
+		delete(r.timers, key)
+		r.lock.Unlock()
+
+		if err := r.Restart(ns, configmapName, checksum); err != nil {
+			log.Errorf("restarting consumers of config map %s: %v", key, err)
+		}
+	})
+}
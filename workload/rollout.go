@@ -0,0 +1,107 @@
+package workload
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+// pollInterval is how often the rollout of the consumers is checked
+const pollInterval = 5 * time.Second
+
+// ErrStopped is returned by WaitRollout when it is stopped before the
+// rollout is over
+var ErrStopped = errors.New("stopped waiting for the rollout")
+
+// WaitRollout waits for every Deployment of the namespace consuming any of
+// the ConfigMaps, e.g. a ConfigMap and its published generation, to complete
+// its rollout with all of its replicas available. It fails as soon as a
+// Deployment exceeds its progress deadline, or when they are not all healthy
+// within window, and returns ErrStopped once stopCh is closed.
+func WaitRollout(client kubernetes.Interface, ns string, configmapNames []string, window time.Duration, stopCh <-chan struct{}) error {
+	timeout := time.NewTimer(window)
+	defer timeout.Stop()
+	done := make(chan struct{})
+	defer close(done)
+	pollStop := make(chan struct{})
+	go func() {
+		defer close(pollStop)
+		select {
+		case <-stopCh:
+		case <-timeout.C:
+		case <-done:
+		}
+	}()
+
+	var pending string
+	err := wait.PollImmediateUntil(pollInterval, func() (bool, error) {
+		workloads, err := List(client, ns, labels.Everything())
+		if err != nil {
+			return false, err
+		}
+
+		pending = ""
+		for _, w := range workloads {
+			if w.deployment == nil || !referencesAny(w, configmapNames) {
+				continue
+			}
+			if failed := progressDeadlineExceeded(w.deployment); failed != "" {
+				return false, fmt.Errorf("deployment %s/%s failed its rollout: %s", ns, w.Name, failed)
+			}
+			if !rolledOut(w.deployment) {
+				pending = w.Name
+			}
+		}
+		return pending == "", nil
+	}, pollStop)
+	if err != wait.ErrWaitTimeout {
+		return err
+	}
+	select {
+	case <-stopCh:
+		return ErrStopped
+	default:
+	}
+	return fmt.Errorf("deployment %s/%s did not become healthy within %s", ns, pending, window)
+}
+
+// referencesAny tells whether the workload refers to any of the ConfigMaps
+func referencesAny(w Workload, configmapNames []string) bool {
+	for _, name := range configmapNames {
+		if w.ReferencesConfigMap(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// rolledOut tells whether the latest generation of the Deployment is fully
+// rolled out and available
+func rolledOut(d *apps_v1.Deployment) bool {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	return d.Status.ObservedGeneration >= d.Generation &&
+		d.Status.UpdatedReplicas == replicas &&
+		d.Status.Replicas == replicas &&
+		d.Status.AvailableReplicas == replicas &&
+		d.Status.ReadyReplicas == replicas
+}
+
+// progressDeadlineExceeded returns the message of the Progressing condition
+// of the Deployment when it reports that the progress deadline was exceeded
+func progressDeadlineExceeded(d *apps_v1.Deployment) string {
+	for _, c := range d.Status.Conditions {
+		if c.Type == apps_v1.DeploymentProgressing && c.Status == core_v1.ConditionFalse && c.Reason == "ProgressDeadlineExceeded" {
+			return c.Message
+		}
+	}
+	return ""
+}
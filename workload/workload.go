@@ -0,0 +1,133 @@
+package workload
+
+import (
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Workload is a Deployment, a StatefulSet or a DaemonSet, seen through its
+// pod template
+type Workload struct {
+	Kind     string
+	Name     string
+	Template *core_v1.PodTemplateSpec
+
+	deployment  *apps_v1.Deployment
+	statefulSet *apps_v1.StatefulSet
+	daemonSet   *apps_v1.DaemonSet
+}
+
+// List returns the Deployments, StatefulSets and DaemonSets of the namespace
+// matching the selector
+func List(client kubernetes.Interface, ns string, selector labels.Selector) ([]Workload, error) {
+	opts := meta_v1.ListOptions{LabelSelector: selector.String()}
+
+	deployments, err := client.AppsV1().Deployments(ns).List(opts)
+	if err != nil {
+		return nil, err
+	}
+	statefulSets, err := client.AppsV1().StatefulSets(ns).List(opts)
+	if err != nil {
+		return nil, err
+	}
+	daemonSets, err := client.AppsV1().DaemonSets(ns).List(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var workloads []Workload
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		workloads = append(workloads, Workload{Kind: "Deployment", Name: d.Name, Template: &d.Spec.Template, deployment: d})
+	}
+	for i := range statefulSets.Items {
+		s := &statefulSets.Items[i]
+		workloads = append(workloads, Workload{Kind: "StatefulSet", Name: s.Name, Template: &s.Spec.Template, statefulSet: s})
+	}
+	for i := range daemonSets.Items {
+		d := &daemonSets.Items[i]
+		workloads = append(workloads, Workload{Kind: "DaemonSet", Name: d.Name, Template: &d.Spec.Template, daemonSet: d})
+	}
+	return workloads, nil
+}
+
+// Update writes the workload, including any change made to its Template
+func (w Workload) Update(client kubernetes.Interface) error {
+	var err error
+	switch {
+	case w.deployment != nil:
+		_, err = client.AppsV1().Deployments(w.deployment.Namespace).Update(w.deployment)
+	case w.statefulSet != nil:
+		_, err = client.AppsV1().StatefulSets(w.statefulSet.Namespace).Update(w.statefulSet)
+	case w.daemonSet != nil:
+		_, err = client.AppsV1().DaemonSets(w.daemonSet.Namespace).Update(w.daemonSet)
+	}
+	return err
+}
+
+// Patch applies a strategic merge patch to the workload
+func (w Workload) Patch(client kubernetes.Interface, patch []byte) error {
+	var err error
+	switch {
+	case w.deployment != nil:
+		_, err = client.AppsV1().Deployments(w.deployment.Namespace).Patch(w.Name, types.StrategicMergePatchType, patch)
+	case w.statefulSet != nil:
+		_, err = client.AppsV1().StatefulSets(w.statefulSet.Namespace).Patch(w.Name, types.StrategicMergePatchType, patch)
+	case w.daemonSet != nil:
+		_, err = client.AppsV1().DaemonSets(w.daemonSet.Namespace).Patch(w.Name, types.StrategicMergePatchType, patch)
+	}
+	return err
+}
+
+// ReferencesConfigMap tells whether the pod template of the workload refers
+// to the named ConfigMap
+func (w Workload) ReferencesConfigMap(name string) bool {
+	found := false
+	VisitConfigMapNames(&w.Template.Spec, func(ref *string) {
+		if *ref == name {
+			found = true
+		}
+	})
+	return found
+}
+
+// VisitConfigMapNames calls visit with every ConfigMap name the pod spec
+// refers to, be it through volumes, projected volumes, envFrom or env
+// valueFrom. visit is handed a pointer so that it can rewrite the name.
+func VisitConfigMapNames(spec *core_v1.PodSpec, visit func(name *string)) {
+	for i := range spec.Volumes {
+		v := &spec.Volumes[i]
+		if v.ConfigMap != nil {
+			visit(&v.ConfigMap.Name)
+		}
+		if v.Projected != nil {
+			for j := range v.Projected.Sources {
+				if cm := v.Projected.Sources[j].ConfigMap; cm != nil {
+					visit(&cm.Name)
+				}
+			}
+		}
+	}
+
+	visitContainers := func(containers []core_v1.Container) {
+		for i := range containers {
+			c := &containers[i]
+			for j := range c.EnvFrom {
+				if ref := c.EnvFrom[j].ConfigMapRef; ref != nil {
+					visit(&ref.Name)
+				}
+			}
+			for j := range c.Env {
+				if from := c.Env[j].ValueFrom; from != nil && from.ConfigMapKeyRef != nil {
+					visit(&from.ConfigMapKeyRef.Name)
+				}
+			}
+		}
+	}
+	visitContainers(spec.InitContainers)
+	visitContainers(spec.Containers)
+}